@@ -3,12 +3,14 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,46 +20,221 @@ type AnalyzeRequest struct {
 	Resume         string `json:"resume"`         // Base64 encoded file
 	ResumeFileName string `json:"resumeFileName"` // Original filename
 	JobDescription string `json:"jobDescription"` // Job description text
+	Profile        string `json:"profile"`        // Scoring profile, e.g. "software_engineer"; empty for the default
+	// Emulation scores as a kind of commercial ATS would, e.g. "legacy-parser"; empty for standard
+	Emulation         string `json:"emulation"`
+	CompareEmulations bool   `json:"compareEmulations"` // Also return a score for every emulation mode
+	// IncludeScoreInput returns the scorer request, which holds the resume's text, for /api/simulate
+	IncludeScoreInput bool `json:"includeScoreInput"`
 }
 
 // AnalyzeResponse represents the analysis result
 type AnalyzeResponse struct {
-	Score           int                     `json:"score"`
-	MatchedSkills   []string                `json:"matchedSkills"`
-	MissingSkills   []string                `json:"missingSkills"`
-	Sections        map[string]SectionScore `json:"sections"`
-	OverallFeedback string                  `json:"overallFeedback"`
+	Score                 int                     `json:"score"`
+	Profile               string                  `json:"profile"`
+	KnockedOut            bool                    `json:"knockedOut"`
+	Knockouts             []Knockout              `json:"knockouts"`
+	MatchedSkills         []string                `json:"matchedSkills"`
+	MissingSkills         []string                `json:"missingSkills"`
+	MatchedCertifications []string                `json:"matchedCertifications"`
+	MissingCertifications []string                `json:"missingCertifications"`
+	SkillEvidence         []SkillEvidence         `json:"skillEvidence"`
+	Sections              map[string]SectionScore `json:"sections"`
+	OverallFeedback       string                  `json:"overallFeedback"`
+	Education             EducationMatch          `json:"education"`
+	TitleAlignment        TitleAlignment          `json:"titleAlignment"`
+	Rewrites              []BulletRewrite         `json:"rewrites"`
+	AppliedRules          []AppliedRule           `json:"appliedRules"`
+	Explanation           ScoreExplanation        `json:"explanation"`
+	Emulation             string                  `json:"emulation"`
+	Emulations            []EmulationScore        `json:"emulations,omitempty"`
+	ScoreInput            json.RawMessage         `json:"scoreInput,omitempty"` // Scorer request, sent back to /api/simulate
+	Language              LanguageReport          `json:"language"`
+	Warnings              []string                `json:"warnings"`
 }
 
 // SectionScore represents score for a resume section
 type SectionScore struct {
-	Score    int    `json:"score"`
+	Score    int      `json:"score"`
+	Feedback string   `json:"feedback"`
+	Details  []string `json:"details,omitempty"`
+}
+
+// EducationMatch reports whether the resume meets the JD's degree requirement
+type EducationMatch struct {
+	Status     string `json:"status"` // exceeded, met, unmet or not_required
+	Required   string `json:"required,omitempty"`
+	Highest    string `json:"highest,omitempty"`
+	FieldMatch bool   `json:"fieldMatch"`
+	Equivalent bool   `json:"equivalent"`
+}
+
+// TitleAlignment compares the JD's target title and seniority with the candidate's titles
+type TitleAlignment struct {
+	TargetTitle        string   `json:"targetTitle"`
+	TargetSeniority    string   `json:"targetSeniority"`
+	TargetRank         int      `json:"targetRank"`
+	CandidateTitle     string   `json:"candidateTitle"`
+	CandidateSeniority string   `json:"candidateSeniority"`
+	CandidateRank      int      `json:"candidateRank"`
+	Titles             []string `json:"titles"`
+	BestMatch          string   `json:"bestMatch"`
+	Similarity         float64  `json:"similarity"`
+	SeniorityGap       int      `json:"seniorityGap"`
+}
+
+// BulletRewrite is a suggested rewrite of a weak experience bullet; bracketed text is a placeholder
+type BulletRewrite struct {
+	Original  string   `json:"original"`
+	Suggested string   `json:"suggested"`
+	Reasons   []string `json:"reasons"`
+}
+
+// EmulationScore is the score one emulated ATS would give
+type EmulationScore struct {
+	Mode         string   `json:"mode"`
+	Description  string   `json:"description"`
+	Score        int      `json:"score"`
+	KnockedOut   bool     `json:"knockedOut"`
+	LostSkills   []string `json:"lostSkills"`
+	LostSections []string `json:"lostSections"`
+}
+
+// Knockout is a hard requirement that would get the resume rejected before scoring
+type Knockout struct {
+	Criterion string `json:"criterion"` // certification, work_authorization, degree or minimum_years
+	Reason    string `json:"reason"`
+}
+
+// AppliedRule is a scoring rule that fired, with the score change it made
+type AppliedRule struct {
+	Rule     string `json:"rule"`
+	Target   string `json:"target"` // Section name or "overall"
+	Delta    int    `json:"delta"`
 	Feedback string `json:"feedback"`
 }
 
+// ScoreExplanation shows how the scorer combined its components into the overall score
+type ScoreExplanation struct {
+	Profile       string                 `json:"profile"`
+	Components    []ComponentExplanation `json:"components"`
+	Sections      []SectionExplanation   `json:"sections"`
+	WeightedScore float64                `json:"weightedScore"`
+	Rules         []AppliedRule          `json:"rules"`
+	Adjustments   []ScoreAdjustment      `json:"adjustments"` // Clamping, rules and penalties applied after weighting
+	Final         int                    `json:"final"`
+}
+
+// ComponentExplanation is one component's raw score, weight and contribution to the weighted score
+type ComponentExplanation struct {
+	Name            string  `json:"name"`
+	RawScore        float64 `json:"rawScore"`
+	Weight          float64 `json:"weight"`
+	EffectiveWeight float64 `json:"effectiveWeight"`
+	Contribution    float64 `json:"contribution"`
+	Included        bool    `json:"included"`
+	Note            string  `json:"note,omitempty"`
+}
+
+// SectionExplanation is one section's score and weight in the section average
+type SectionExplanation struct {
+	Section string  `json:"section"`
+	Score   int     `json:"score"`
+	Weight  float64 `json:"weight"`
+}
+
+// ScoreAdjustment is a step that changed the score after weighting
+type ScoreAdjustment struct {
+	Step   string `json:"step"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Reason string `json:"reason"`
+}
+
+// SkillEvidence shows where a matched skill appears in the resume
+type SkillEvidence struct {
+	Skill    string            `json:"skill"`
+	Sections []string          `json:"sections"`
+	Snippets []EvidenceSnippet `json:"snippets"`
+}
+
+// EvidenceSnippet is a resume sentence mentioning a skill, with character offsets into the parsed text
+type EvidenceSnippet struct {
+	Text    string `json:"text"`
+	Section string `json:"section"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+}
+
+// LanguageReport describes the detected resume and JD languages
+type LanguageReport struct {
+	Resume         LanguageGuess `json:"resume"`
+	JobDescription LanguageGuess `json:"jobDescription"`
+	Mismatch       bool          `json:"mismatch"`
+}
+
+// LanguageGuess is a detected language with its confidence
+type LanguageGuess struct {
+	Code       string  `json:"code"`
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"`
+}
+
 // ParseResponse from resume-parser service
 type ParseResponse struct {
-	Text     string   `json:"text"`
-	Sections []string `json:"sections"`
-	Error    string   `json:"error,omitempty"`
+	Text         string   `json:"text"`
+	Sections     []string `json:"sections"`
+	HiddenText   []string `json:"hiddenText"`
+	HeadingHints []string `json:"headingHints"`
+	LegacyUnread []string `json:"legacyUnread"`
+	Error        string   `json:"error,omitempty"`
 }
 
 // NLPAnalysisResponse from nlp-service
 type NLPAnalysisResponse struct {
-	Keywords        []string          `json:"keywords"`
-	Skills          []string          `json:"skills"`
-	Sections        map[string]string `json:"sections"`
-	SimilarityScore float64           `json:"similarityScore"`
-	MatchedSkills   []string          `json:"matchedSkills"`
-	MissingSkills   []string          `json:"missingSkills"`
-	Error           string            `json:"error,omitempty"`
+	Keywords              []string          `json:"keywords"`
+	Skills                []string          `json:"skills"`
+	Sections              map[string]string `json:"sections"`
+	SectionBlocks         json.RawMessage   `json:"sectionBlocks"` // Passed through to the scorer
+	SimilarityScore       float64           `json:"similarityScore"`
+	MatchedSkills         []string          `json:"matchedSkills"`
+	MissingSkills         []string          `json:"missingSkills"`
+	JDSkillTerms          json.RawMessage   `json:"jdSkillTerms"` // Passed through to the scorer
+	SkillEvidence         []SkillEvidence   `json:"skillEvidence"`
+	Certifications        []string          `json:"certifications"`
+	CertificationTerms    json.RawMessage   `json:"certificationTerms"` // Passed through to the scorer
+	MatchedCertifications []string          `json:"matchedCertifications"`
+	MissingCertifications []string          `json:"missingCertifications"`
+	Degrees               json.RawMessage   `json:"degrees"` // Passed through to the scorer
+	EducationRequirement  json.RawMessage   `json:"educationRequirement"`
+	JobRequirements       json.RawMessage   `json:"jobRequirements"` // Passed through to the scorer
+	WorkAuthorization     string            `json:"workAuthorization"`
+	Stuffing              json.RawMessage   `json:"stuffing"` // Passed through to the scorer
+	SkillProfiles         json.RawMessage   `json:"skillProfiles"`
+	RequiresCurrentUse    bool              `json:"requiresCurrentUse"`
+	TitleAlignment        TitleAlignment    `json:"titleAlignment"`
+	ExperienceYears       int               `json:"experienceYears"`
+	Readability           json.RawMessage   `json:"readability"` // Passed through to the scorer
+	Language              LanguageReport    `json:"language"`
+	Warnings              []string          `json:"warnings"`
+	Error                 string            `json:"error,omitempty"`
 }
 
 // ScoringResponse from ats-scorer service
 type ScoringResponse struct {
 	Score           int                     `json:"score"`
+	Profile         string                  `json:"profile"`
+	KnockedOut      bool                    `json:"knockedOut"`
+	Knockouts       []Knockout              `json:"knockouts"`
 	Sections        map[string]SectionScore `json:"sections"`
 	OverallFeedback string                  `json:"overallFeedback"`
+	Education       EducationMatch          `json:"education"`
+	Rewrites        []BulletRewrite         `json:"rewrites"`
+	AppliedRules    []AppliedRule           `json:"appliedRules"`
+	Explanation     ScoreExplanation        `json:"explanation"`
+	Emulation       string                  `json:"emulation"`
+	Emulations      []EmulationScore        `json:"emulations,omitempty"`
+	Warnings        []string                `json:"warnings"`
 	Error           string                  `json:"error,omitempty"`
 }
 
@@ -109,7 +286,7 @@ func AnalyzeResume(c *fiber.Ctx) error {
 	}
 
 	// Step 2: NLP Analysis
-	nlpResp, err := callNLPService(parseResp.Text, req.JobDescription)
+	nlpResp, err := callNLPService(parseResp, req.JobDescription)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": fmt.Sprintf("Failed to analyze resume: %v", err),
@@ -117,20 +294,39 @@ func AnalyzeResume(c *fiber.Ctx) error {
 	}
 
 	// Step 3: Calculate ATS Score
-	scoreResp, err := callScoringService(nlpResp)
+	scoreInput := buildScoringPayload(parseResp, nlpResp, req)
+	scoreResp, err := callScoringService(scoreInput)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
 			"error": fmt.Sprintf("Failed to calculate score: %v", err),
 		})
 	}
 
 	// Build response
 	response := AnalyzeResponse{
-		Score:           scoreResp.Score,
-		MatchedSkills:   nlpResp.MatchedSkills,
-		MissingSkills:   nlpResp.MissingSkills,
-		Sections:        scoreResp.Sections,
-		OverallFeedback: scoreResp.OverallFeedback,
+		Score:                 scoreResp.Score,
+		Profile:               scoreResp.Profile,
+		KnockedOut:            scoreResp.KnockedOut,
+		Knockouts:             scoreResp.Knockouts,
+		MatchedSkills:         nlpResp.MatchedSkills,
+		MissingSkills:         nlpResp.MissingSkills,
+		MatchedCertifications: nlpResp.MatchedCertifications,
+		MissingCertifications: nlpResp.MissingCertifications,
+		SkillEvidence:         nlpResp.SkillEvidence,
+		Sections:              scoreResp.Sections,
+		OverallFeedback:       scoreResp.OverallFeedback,
+		Education:             scoreResp.Education,
+		TitleAlignment:        nlpResp.TitleAlignment,
+		Rewrites:              scoreResp.Rewrites,
+		AppliedRules:          scoreResp.AppliedRules,
+		Explanation:           scoreResp.Explanation,
+		Emulation:             scoreResp.Emulation,
+		Emulations:            scoreResp.Emulations,
+		Language:              nlpResp.Language,
+		Warnings:              append(nlpResp.Warnings, scoreResp.Warnings...),
+	}
+	if req.IncludeScoreInput {
+		response.ScoreInput = scoreInput
 	}
 
 	return c.JSON(response)
@@ -173,18 +369,19 @@ func callParseService(resumeBase64, fileName string) (*ParseResponse, error) {
 	}
 
 	if parseResp.Error != "" {
-		return nil, fmt.Errorf(parseResp.Error)
+		return nil, errors.New(parseResp.Error)
 	}
 
 	return &parseResp, nil
 }
 
-func callNLPService(resumeText, jobDescription string) (*NLPAnalysisResponse, error) {
+func callNLPService(parseResp *ParseResponse, jobDescription string) (*NLPAnalysisResponse, error) {
 	url := getServiceURL("nlp-service") + "/analyze"
 
-	payload := map[string]string{
-		"resumeText":     resumeText,
+	payload := map[string]interface{}{
+		"resumeText":     parseResp.Text,
 		"jobDescription": jobDescription,
+		"headingHints":   parseResp.HeadingHints,
 	}
 
 	jsonData, _ := json.Marshal(payload)
@@ -216,25 +413,72 @@ func callNLPService(resumeText, jobDescription string) (*NLPAnalysisResponse, er
 	}
 
 	if nlpResp.Error != "" {
-		return nil, fmt.Errorf(nlpResp.Error)
+		return nil, errors.New(nlpResp.Error)
 	}
 
 	return &nlpResp, nil
 }
 
-func callScoringService(nlpResp *NLPAnalysisResponse) (*ScoringResponse, error) {
-	url := getServiceURL("ats-scorer") + "/score"
-
+// buildScoringPayload assembles the ats-scorer request from the parse and NLP results
+func buildScoringPayload(parseResp *ParseResponse, nlpResp *NLPAnalysisResponse, req AnalyzeRequest) json.RawMessage {
 	payload := map[string]interface{}{
-		"skills":          nlpResp.Skills,
-		"matchedSkills":   nlpResp.MatchedSkills,
-		"missingSkills":   nlpResp.MissingSkills,
-		"sections":        nlpResp.Sections,
-		"similarityScore": nlpResp.SimilarityScore,
+		"skills":                nlpResp.Skills,
+		"matchedSkills":         nlpResp.MatchedSkills,
+		"missingSkills":         nlpResp.MissingSkills,
+		"jdSkillTerms":          nlpResp.JDSkillTerms,
+		"certifications":        nlpResp.Certifications,
+		"certificationTerms":    nlpResp.CertificationTerms,
+		"matchedCertifications": nlpResp.MatchedCertifications,
+		"missingCertifications": nlpResp.MissingCertifications,
+		"degrees":               nlpResp.Degrees,
+		"educationRequirement":  nlpResp.EducationRequirement,
+		"jobRequirements":       nlpResp.JobRequirements,
+		"workAuthorization":     nlpResp.WorkAuthorization,
+		"sections":              nlpResp.Sections,
+		"sectionBlocks":         nlpResp.SectionBlocks,
+		"similarityScore":       nlpResp.SimilarityScore,
+		"stuffing":              nlpResp.Stuffing,
+		"hiddenText":            parseResp.HiddenText,
+		"skillProfiles":         nlpResp.SkillProfiles,
+		"requiresCurrentUse":    nlpResp.RequiresCurrentUse,
+		"referenceYear":         time.Now().Year(),
+		"titleAlignment":        nlpResp.TitleAlignment,
+		"experienceYears":       nlpResp.ExperienceYears,
+		"readability":           nlpResp.Readability,
+		"profile":               req.Profile,
+		"emulation":             req.Emulation,
+		"compareEmulations":     req.CompareEmulations,
+		"legacyUnread":          parseResp.LegacyUnread,
 	}
 
 	jsonData, _ := json.Marshal(payload)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	return jsonData
+}
+
+// requestError is a downstream service rejecting the request as invalid, such as an unknown
+// scoring profile. The gateway answers with the same status instead of a server error.
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// errorStatus returns the status to answer a failed service call with
+func errorStatus(err error) int {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.status
+	}
+	return fiber.StatusInternalServerError
+}
+
+func callScoringService(payload json.RawMessage) (*ScoringResponse, error) {
+	url := getServiceURL("ats-scorer") + "/score"
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(payload))
 	if err != nil {
 		log.Printf("[ERROR] Failed to connect to ats-scorer: %v", err)
 		return nil, fmt.Errorf("failed to connect to scoring service: %v", err)
@@ -246,7 +490,17 @@ func callScoringService(nlpResp *NLPAnalysisResponse) (*ScoringResponse, error)
 	// Check for non-2xx status codes
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		log.Printf("[ERROR] Scoring service returned status %d: %s", resp.StatusCode, string(body[:min(len(body), 500)]))
-		return nil, fmt.Errorf("scoring service returned status %d", resp.StatusCode)
+		// Pass on the scorer's own message, e.g. for an unknown profile, and its status when
+		// the request itself was bad
+		message := fmt.Sprintf("scoring service returned status %d", resp.StatusCode)
+		var errResp ScoringResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+			message = errResp.Error
+		}
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			return nil, &requestError{status: resp.StatusCode, message: message}
+		}
+		return nil, errors.New(message)
 	}
 
 	// Check if response is HTML (Render cold start or error page)
@@ -262,8 +516,49 @@ func callScoringService(nlpResp *NLPAnalysisResponse) (*ScoringResponse, error)
 	}
 
 	if scoreResp.Error != "" {
-		return nil, fmt.Errorf(scoreResp.Error)
+		return nil, errors.New(scoreResp.Error)
 	}
 
 	return &scoreResp, nil
 }
+
+// certificationsResponse is the NLP service's certification name lookup
+type certificationsResponse struct {
+	Certifications []string `json:"certifications"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// callCertificationService looks up the catalog name of each certification name, so aliases
+// such as "AWS SAA" compare equal to the names an analysis reports. Unknown names come back empty.
+func callCertificationService(names []string) ([]string, error) {
+	url := getServiceURL("nlp-service") + "/certifications"
+
+	jsonData, _ := json.Marshal(map[string]interface{}{"names": names})
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		log.Printf("[ERROR] Failed to connect to nlp-service: %v", err)
+		return nil, fmt.Errorf("failed to connect to NLP service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Printf("[ERROR] NLP service returned status %d: %s", resp.StatusCode, string(body[:min(len(body), 500)]))
+		return nil, fmt.Errorf("NLP service returned status %d", resp.StatusCode)
+	}
+
+	var certResp certificationsResponse
+	if err := json.Unmarshal(body, &certResp); err != nil {
+		log.Printf("[ERROR] Failed to parse nlp-service response: %v, body: %s", err, string(body[:min(len(body), 500)]))
+		return nil, fmt.Errorf("invalid response from NLP service: %v", err)
+	}
+	if certResp.Error != "" {
+		return nil, errors.New(certResp.Error)
+	}
+	if len(certResp.Certifications) != len(names) {
+		return nil, fmt.Errorf("invalid response from NLP service: %d names for %d requested", len(certResp.Certifications), len(names))
+	}
+
+	return certResp.Certifications, nil
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SimulateRequest asks how hypothetical edits would change a previous analysis
+type SimulateRequest struct {
+	ScoreInput map[string]json.RawMessage `json:"scoreInput"` // scoreInput from a previous /api/analyze response
+	Edits      SimulationEdits            `json:"edits"`
+}
+
+// SimulationEdits are hypothetical changes to the resume
+type SimulationEdits struct {
+	AddSkills         []string            `json:"addSkills"`
+	AddCertifications []string            `json:"addCertifications"`
+	AddSections       map[string]string   `json:"addSections"` // Section name to the text to add, e.g. "projects"
+	ReplaceBullets    []BulletReplacement `json:"replaceBullets"`
+}
+
+// BulletReplacement swaps one resume line for another
+type BulletReplacement struct {
+	Original    string `json:"original"`
+	Replacement string `json:"replacement"`
+}
+
+// SimulateResponse compares the edited resume's score with the original's
+type SimulateResponse struct {
+	BaseScore       int                     `json:"baseScore"`
+	Score           int                     `json:"score"`
+	Delta           int                     `json:"delta"`
+	BaseKnockedOut  bool                    `json:"baseKnockedOut"`
+	KnockedOut      bool                    `json:"knockedOut"`
+	Knockouts       []Knockout              `json:"knockouts"`
+	Sections        map[string]SectionScore `json:"sections"`
+	SectionDeltas   map[string]int          `json:"sectionDeltas"` // Change in each section's score
+	OverallFeedback string                  `json:"overallFeedback"`
+	Explanation     ScoreExplanation        `json:"explanation"`
+	Applied         []string                `json:"applied"`  // What each edit changed
+	Warnings        []string                `json:"warnings"` // Edits that could not be applied, and what edits don't re-check
+}
+
+// unmatchedTextWarning is returned when edits add text, since skills in it aren't matched again
+const unmatchedTextWarning = "Skills are not matched again in added or replaced text; list them in addSkills to count them."
+
+// scoreInput is a scorer request being edited. Fields the edits don't touch are kept as sent.
+type scoreInput map[string]json.RawMessage
+
+// get decodes a field, leaving value unchanged when the field is missing
+func (in scoreInput) get(key string, value interface{}) error {
+	if raw, ok := in[key]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, value); err != nil {
+			return fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// set encodes a field
+func (in scoreInput) set(key string, value interface{}) {
+	data, _ := json.Marshal(value)
+	in[key] = data
+}
+
+// SimulateEdits handles the what-if endpoint: it scores a previous analysis's scorer input
+// before and after hypothetical edits, without re-parsing the resume. Similarity, readability
+// and title fit come from the original analysis and are not recomputed, and neither is skill
+// matching: a skill in added sections or replaced bullets only counts when it is also in addSkills.
+func SimulateEdits(c *fiber.Ctx) error {
+	var req SimulateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if len(req.ScoreInput) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "scoreInput from a previous analysis is required",
+		})
+	}
+
+	// Only the overall score is compared, so skip the per-emulation scores
+	base := scoreInput(req.ScoreInput)
+	base.set("compareEmulations", false)
+
+	edited := make(scoreInput, len(base))
+	for key, value := range base {
+		edited[key] = value
+	}
+	// Compare added certifications by their catalog names, as the analysis reports them
+	if len(req.Edits.AddCertifications) > 0 {
+		canonical, err := callCertificationService(req.Edits.AddCertifications)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": fmt.Sprintf("Failed to look up certifications: %v", err),
+			})
+		}
+		req.Edits.AddCertifications = canonicalNames(req.Edits.AddCertifications, canonical)
+	}
+
+	applied, warnings, err := applyEdits(edited, req.Edits)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	basePayload, _ := json.Marshal(base)
+	baseResp, err := callScoringService(basePayload)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": fmt.Sprintf("Failed to score the original resume: %v", err),
+		})
+	}
+	editedPayload, _ := json.Marshal(edited)
+	editedResp, err := callScoringService(editedPayload)
+	if err != nil {
+		return c.Status(errorStatus(err)).JSON(fiber.Map{
+			"error": fmt.Sprintf("Failed to score the edited resume: %v", err),
+		})
+	}
+
+	sectionDeltas := make(map[string]int)
+	for name, section := range editedResp.Sections {
+		if delta := section.Score - baseResp.Sections[name].Score; delta != 0 {
+			sectionDeltas[name] = delta
+		}
+	}
+
+	return c.JSON(SimulateResponse{
+		BaseScore:       baseResp.Score,
+		Score:           editedResp.Score,
+		Delta:           editedResp.Score - baseResp.Score,
+		BaseKnockedOut:  baseResp.KnockedOut,
+		KnockedOut:      editedResp.KnockedOut,
+		Knockouts:       editedResp.Knockouts,
+		Sections:        editedResp.Sections,
+		SectionDeltas:   sectionDeltas,
+		OverallFeedback: editedResp.OverallFeedback,
+		Explanation:     editedResp.Explanation,
+		Applied:         applied,
+		Warnings:        warnings,
+	})
+}
+
+// applyEdits changes a scorer input the way the edits would change the parsed resume.
+// It returns a description of each change and a warning for each edit that changed nothing.
+func applyEdits(in scoreInput, edits SimulationEdits) ([]string, []string, error) {
+	applied := make([]string, 0)
+	warnings := make([]string, 0)
+
+	var skills, matchedSkills, missingSkills []string
+	var certifications, matchedCertifications, missingCertifications []string
+	var sections map[string]string
+	var blocks []map[string]interface{}
+	for key, value := range map[string]interface{}{
+		"skills": &skills, "matchedSkills": &matchedSkills, "missingSkills": &missingSkills,
+		"certifications": &certifications, "matchedCertifications": &matchedCertifications,
+		"missingCertifications": &missingCertifications, "sections": &sections, "sectionBlocks": &blocks,
+	} {
+		if err := in.get(key, value); err != nil {
+			return nil, nil, err
+		}
+	}
+	if sections == nil {
+		sections = make(map[string]string)
+	}
+
+	// addToSection adds text as a new line of a section and its heading block, creating both if needed
+	addToSection := func(name, text string) {
+		sections[name] = appendLine(sections[name], text)
+		for _, block := range blocks {
+			if block["name"] == name {
+				content, _ := block["content"].(string)
+				block["content"] = appendLine(content, text)
+				return
+			}
+		}
+		first, size := utf8.DecodeRuneInString(name)
+		heading := string(unicode.ToUpper(first)) + name[size:]
+		blocks = append(blocks, map[string]interface{}{"name": name, "heading": heading, "content": text})
+	}
+
+	for _, skill := range edits.AddSkills {
+		skill = strings.TrimSpace(skill)
+		switch {
+		case skill == "":
+			continue
+		case containsFold(matchedSkills, skill):
+			warnings = append(warnings, fmt.Sprintf("%s is already matched.", skill))
+			continue
+		case containsFold(missingSkills, skill):
+			missingSkills = removeFold(missingSkills, skill)
+			matchedSkills = append(matchedSkills, skill)
+			applied = append(applied, fmt.Sprintf("Added %s, which the job asks for.", skill))
+		default:
+			applied = append(applied, fmt.Sprintf("Added %s; the job doesn't ask for it, so skill match is unchanged.", skill))
+		}
+		if !containsFold(skills, skill) {
+			skills = append(skills, skill)
+		}
+		addToSection("skills", skill)
+	}
+
+	for _, certification := range edits.AddCertifications {
+		certification = strings.TrimSpace(certification)
+		switch {
+		case certification == "":
+			continue
+		case containsFold(certifications, certification):
+			warnings = append(warnings, fmt.Sprintf("%s is already on the resume.", certification))
+			continue
+		case containsFold(missingCertifications, certification):
+			missingCertifications = removeFold(missingCertifications, certification)
+			matchedCertifications = append(matchedCertifications, certification)
+			applied = append(applied, fmt.Sprintf("Added the %s certification, which the job asks for.", certification))
+		default:
+			applied = append(applied, fmt.Sprintf("Added the %s certification.", certification))
+		}
+		certifications = append(certifications, certification)
+		addToSection("certifications", certification)
+	}
+
+	names := make([]string, 0, len(edits.AddSections))
+	for name := range edits.AddSections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		text := strings.Join(strings.Fields(edits.AddSections[name]), " ")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || text == "" {
+			continue
+		}
+		addToSection(name, text)
+		applied = append(applied, fmt.Sprintf("Added %d words to the %s section.", len(strings.Fields(text)), name))
+	}
+
+	// A bullet may wrap across lines of section content, so any run of whitespace matches
+	for _, edit := range edits.ReplaceBullets {
+		replacement := strings.Join(strings.Fields(edit.Replacement), " ")
+		original := wordsPattern(edit.Original)
+		if original == nil {
+			continue
+		}
+		// Search sections in name order so a line found in two sections is replaced the same way every time
+		sectionNames := make([]string, 0, len(sections))
+		for name := range sections {
+			sectionNames = append(sectionNames, name)
+		}
+		sort.Strings(sectionNames)
+		section := ""
+		for _, name := range sectionNames {
+			if content := sections[name]; original.MatchString(content) {
+				sections[name] = replaceFirst(original, content, replacement)
+				section = name
+				break
+			}
+		}
+		if section == "" {
+			warnings = append(warnings, fmt.Sprintf("%q was not found on the resume.", edit.Original))
+			continue
+		}
+		for _, block := range blocks {
+			if content, _ := block["content"].(string); block["name"] == section && original.MatchString(content) {
+				block["content"] = replaceFirst(original, content, replacement)
+				break
+			}
+		}
+		applied = append(applied, fmt.Sprintf("Replaced %q.", edit.Original))
+	}
+
+	if len(edits.AddSections) > 0 || len(edits.ReplaceBullets) > 0 {
+		warnings = append(warnings, unmatchedTextWarning)
+	}
+
+	in.set("skills", skills)
+	in.set("matchedSkills", matchedSkills)
+	in.set("missingSkills", missingSkills)
+	in.set("certifications", certifications)
+	in.set("matchedCertifications", matchedCertifications)
+	in.set("missingCertifications", missingCertifications)
+	in.set("sections", sections)
+	in.set("sectionBlocks", blocks)
+	return applied, warnings, nil
+}
+
+// appendLine adds text as the last line of section content
+func appendLine(content, text string) string {
+	if content = strings.TrimSpace(content); content != "" {
+		return content + "\n" + text
+	}
+	return text
+}
+
+// wordsPattern matches the words of text separated by any whitespace, or is nil for blank text
+func wordsPattern(text string) *regexp.Regexp {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return regexp.MustCompile(strings.Join(words, `\s+`))
+}
+
+// replaceFirst replaces the first match of pattern in content with replacement, taken literally
+func replaceFirst(pattern *regexp.Regexp, content, replacement string) string {
+	loc := pattern.FindStringIndex(content)
+	if loc == nil {
+		return content
+	}
+	return content[:loc[0]] + replacement + content[loc[1]:]
+}
+
+// canonicalNames replaces each name with its catalog name, keeping names the catalog doesn't know
+func canonicalNames(names, canonical []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = name
+		if canonical[i] != "" {
+			result[i] = canonical[i]
+		}
+	}
+	return result
+}
+
+// containsFold reports whether list holds term, ignoring case
+func containsFold(list []string, term string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeFold removes every case-insensitive match of term from list
+func removeFold(list []string, term string) []string {
+	kept := make([]string, 0, len(list))
+	for _, item := range list {
+		if !strings.EqualFold(item, term) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyEditsAddSectionHeading(t *testing.T) {
+	tests := []struct {
+		section string
+		want    string
+	}{
+		{"projects", "Projects"},
+		{"éducation", "Éducation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			in := scoreInput{}
+			if _, _, err := applyEdits(in, SimulationEdits{AddSections: map[string]string{tt.section: "Built a compiler"}}); err != nil {
+				t.Fatal(err)
+			}
+			var blocks []map[string]interface{}
+			if err := in.get("sectionBlocks", &blocks); err != nil {
+				t.Fatal(err)
+			}
+			if len(blocks) != 1 || blocks[0]["heading"] != tt.want {
+				t.Errorf("blocks = %v, want one block headed %q", blocks, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyEditsReplaceBulletsDeterministic(t *testing.T) {
+	const runs = 50
+	bullet := "Led a team of five engineers"
+
+	for i := 0; i < runs; i++ {
+		in := scoreInput{}
+		in.set("sections", map[string]string{
+			"summary":    bullet,
+			"experience": bullet,
+			"projects":   bullet,
+		})
+		in.set("sectionBlocks", []map[string]interface{}{
+			{"name": "summary", "content": bullet},
+			{"name": "experience", "content": bullet},
+			{"name": "projects", "content": bullet},
+		})
+		edits := SimulationEdits{ReplaceBullets: []BulletReplacement{{Original: bullet, Replacement: "Led eight engineers"}}}
+		if _, _, err := applyEdits(in, edits); err != nil {
+			t.Fatal(err)
+		}
+
+		var sections map[string]string
+		var blocks []map[string]interface{}
+		in.get("sections", &sections)
+		in.get("sectionBlocks", &blocks)
+		if sections["experience"] != "Led eight engineers" || sections["projects"] != bullet || sections["summary"] != bullet {
+			t.Fatalf("run %d: sections = %v, want only experience replaced", i, sections)
+		}
+		if blocks[1]["content"] != "Led eight engineers" || blocks[0]["content"] != bullet {
+			t.Fatalf("run %d: blocks = %v, want only the experience block replaced", i, blocks)
+		}
+	}
+}
+
+func TestApplyEditsCanonicalCertification(t *testing.T) {
+	const saa = "AWS Certified Solutions Architect - Associate"
+
+	in := scoreInput{}
+	in.set("missingCertifications", []string{saa})
+	names := canonicalNames([]string{"AWS SAA", "Made-up Cert"}, []string{saa, ""})
+	if want := []string{saa, "Made-up Cert"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("canonicalNames = %q, want %q", names, want)
+	}
+
+	applied, _, err := applyEdits(in, SimulationEdits{AddCertifications: names[:1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var missing, matched []string
+	in.get("missingCertifications", &missing)
+	in.get("matchedCertifications", &matched)
+	if len(missing) != 0 || !reflect.DeepEqual(matched, []string{saa}) {
+		t.Errorf("missing = %q, matched = %q, want the certification matched", missing, matched)
+	}
+	if len(applied) != 1 {
+		t.Errorf("applied = %q, want one change", applied)
+	}
+}
+
+func TestApplyEditsKeepsLines(t *testing.T) {
+	experience := "Acme 2020 - Present\n• Worked on internal tooling for\nthe platform team\n• Led a team of 4 engineers"
+
+	in := scoreInput{}
+	in.set("sections", map[string]string{"experience": experience})
+	edits := SimulationEdits{
+		ReplaceBullets: []BulletReplacement{{
+			Original:    "Worked on internal tooling for the platform team",
+			Replacement: "Built internal tooling used by 40 engineers",
+		}},
+		AddSections: map[string]string{"experience": "• Cut build times by 50%"},
+	}
+	if _, warnings, err := applyEdits(in, edits); err != nil || !reflect.DeepEqual(warnings, []string{unmatchedTextWarning}) {
+		t.Fatalf("applyEdits: %v, warnings %q", err, warnings)
+	}
+
+	var sections map[string]string
+	in.get("sections", &sections)
+	want := "Acme 2020 - Present\n• Built internal tooling used by 40 engineers\n• Led a team of 4 engineers\n• Cut build times by 50%"
+	if sections["experience"] != want {
+		t.Errorf("experience = %q, want %q", sections["experience"], want)
+	}
+}
+
+func TestApplyEditsUnmatchedTextWarning(t *testing.T) {
+	tests := []struct {
+		name  string
+		edits SimulationEdits
+		want  []string
+	}{
+		{"added skill", SimulationEdits{AddSkills: []string{"terraform"}}, []string{}},
+		{"added section", SimulationEdits{AddSections: map[string]string{"projects": "Terraform modules for AWS"}}, []string{unmatchedTextWarning}},
+		{
+			name:  "replaced bullet",
+			edits: SimulationEdits{ReplaceBullets: []BulletReplacement{{Original: "Led a team", Replacement: "Led a team using Terraform"}}},
+			want:  []string{unmatchedTextWarning},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := scoreInput{}
+			in.set("sections", map[string]string{"experience": "Led a team"})
+			if _, warnings, err := applyEdits(in, tt.edits); err != nil || !reflect.DeepEqual(warnings, tt.want) {
+				t.Errorf("applyEdits: %v, warnings = %q, want %q", err, warnings, tt.want)
+			}
+		})
+	}
+}
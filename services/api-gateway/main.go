@@ -39,6 +39,7 @@ func main() {
 	// API routes
 	api := app.Group("/api", middleware.ValidateRequest)
 	api.Post("/analyze", handlers.AnalyzeResume)
+	api.Post("/simulate", handlers.SimulateEdits)
 
 	port := getEnv("PORT", "8080")
 	log.Printf("API Gateway starting on port %s", port)
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
 
@@ -13,8 +15,30 @@ import (
 )
 
 func main() {
+	checkRules := flag.String("check-rules", "", "validate a scoring rules file and run its tests, then exit")
+	flag.Parse()
+
 	godotenv.Load()
 
+	if *checkRules != "" {
+		errs := scorer.CheckRuleFile(*checkRules)
+		for _, err := range errs {
+			fmt.Println(err)
+		}
+		if len(errs) > 0 {
+			os.Exit(1)
+		}
+		fmt.Println("All rules are valid and all tests pass.")
+		return
+	}
+
+	if errs := scorer.ConfigErrors(); len(errs) > 0 {
+		for _, err := range errs {
+			log.Printf("Invalid configuration: %s", err)
+		}
+		log.Fatalf("Refusing to start with %d configuration error(s)", len(errs))
+	}
+
 	app := fiber.New()
 
 	app.Use(logger.New())
@@ -25,6 +49,9 @@ func main() {
 		return c.JSON(fiber.Map{"status": "ok", "service": "ats-scorer"})
 	})
 
+	// Effective weights, profiles and rules
+	app.Get("/config", scorer.HandleConfig)
+
 	// Scoring endpoint
 	app.Post("/score", scorer.HandleScore)
 
@@ -0,0 +1,135 @@
+package scorer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// bulletPrefixes mark the start of a bullet line
+var bulletPrefixes = []string{"•", "●", "▪", "◦", "‣", "-", "*", "–", "·", "○", "■", "►"}
+
+var (
+	// percentPattern matches a percentage, e.g. "35%" or "12 percent"
+	percentPattern = regexp.MustCompile(`(?i)\d+(?:\.\d+)?\s*(?:%|percent\b|pct\b)`)
+	// currencyPattern matches an amount of money, e.g. "$1.2M", "€40k" or "2 million USD"
+	currencyPattern = regexp.MustCompile(`(?i)(?:[$€£₹]\s?\d[\d,.]*\s*(?:k|m|mm|b|bn|million|billion|thousand)?\b|` +
+		`\d[\d,.]*\s*(?:k|m|mm|b|bn|million|billion|thousand)?\s*(?:usd|eur|gbp|dollars|euros)\b)`)
+	// multiplierPattern matches a multiplier, e.g. "3x", "10X faster" or "doubled"
+	multiplierPattern = regexp.MustCompile(`(?i)(?:\b\d+(?:\.\d+)?\s*x\b|\b(?:doubled|tripled|quadrupled|halved|(?:two|three|four|five|ten|hundred)fold)\b)`)
+	// outcomeCountPattern matches a count tied to an outcome, e.g. "2M users", "team of 8 engineers" or "800ms to 120ms"
+	outcomeCountPattern = regexp.MustCompile(`(?i)\b\d[\d,.]*\s*(?:k|m|b|million|billion|thousand|hundred)?\+?\s*` +
+		`(?:\w+\s+){0,2}?(?:users|customers|clients|requests|transactions|orders|downloads|visitors|subscribers|` +
+		`members|engineers|developers|people|reports|employees|students|services|microservices|servers|nodes|` +
+		`records|rows|events|messages|queries|tickets|bugs|incidents|deployments|releases|features|projects|` +
+		`countries|markets|stores|teams|accounts|leads|partners|hours|hrs|minutes|mins|seconds|secs|ms|days|weeks|` +
+		`rps|qps|tps|gb|tb|pb)\b`)
+	// phonePattern matches a phone number so its digits are not read as a metric
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().-]{8,}\d`)
+	// dateRangePattern matches employment dates such as "Jan 2020 - Present" or "03/2019"
+	dateRangePattern = regexp.MustCompile(`(?i)\b(?:\d{1,2}/)?(?:19|20)\d{2}\b(?:\s*[-–—]\s*(?:(?:\d{1,2}/)?(?:19|20)\d{2}\b|present|current|now))?`)
+)
+
+// BulletMetrics summarizes how many experience bullets show quantified impact
+type BulletMetrics struct {
+	Total        int
+	Quantified   int
+	Unquantified []string // Bullets without a metric, in resume order
+}
+
+// Ratio is the fraction of bullets with a metric, 0 when there are no bullets
+func (m BulletMetrics) Ratio() float64 {
+	if m.Total == 0 {
+		return 0
+	}
+	return float64(m.Quantified) / float64(m.Total)
+}
+
+// experienceBullets splits an experience section into bullets. The NLP service keeps each
+// resume line on its own line of section content; lines wrapped by the PDF extractor are
+// joined back onto their bullet. When the section has no bullet markers,
+// every line that reads like a sentence counts as a bullet.
+func experienceBullets(content string) []string {
+	lines := strings.Split(content, "\n")
+
+	var bullets []string
+	inBullet := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			inBullet = false
+			continue
+		}
+		if text, ok := trimBulletPrefix(line); ok {
+			bullets = append(bullets, text)
+			inBullet = true
+			continue
+		}
+		// A wrapped bullet continues in lower case
+		first := []rune(line)[0]
+		if inBullet && unicode.IsLower(first) {
+			bullets[len(bullets)-1] += " " + line
+			continue
+		}
+		inBullet = false
+	}
+	if len(bullets) > 0 {
+		return bullets
+	}
+
+	// Without markers, skip short lines such as job titles, companies and dates
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if len(strings.Fields(line)) >= 6 && !dateRangePattern.MatchString(line) {
+			bullets = append(bullets, line)
+		}
+	}
+	return bullets
+}
+
+// trimBulletPrefix removes a bullet marker from line, reporting whether it had one
+func trimBulletPrefix(line string) (string, bool) {
+	for _, prefix := range bulletPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix)), true
+		}
+	}
+	return line, false
+}
+
+// hasQuantifiedImpact reports whether a bullet states a measurable result: a percentage,
+// an amount of money, a multiplier or a count tied to an outcome. Dates and phone numbers
+// do not count.
+func hasQuantifiedImpact(bullet string) bool {
+	bullet = phonePattern.ReplaceAllString(bullet, " ")
+	bullet = dateRangePattern.ReplaceAllString(bullet, " ")
+
+	return percentPattern.MatchString(bullet) ||
+		currencyPattern.MatchString(bullet) ||
+		multiplierPattern.MatchString(bullet) ||
+		outcomeCountPattern.MatchString(bullet)
+}
+
+// analyzeBulletMetrics counts the bullets that show quantified impact
+func analyzeBulletMetrics(bullets []string) BulletMetrics {
+	metrics := BulletMetrics{Unquantified: make([]string, 0)}
+	for _, bullet := range bullets {
+		metrics.Total++
+		if hasQuantifiedImpact(bullet) {
+			metrics.Quantified++
+		} else {
+			metrics.Unquantified = append(metrics.Unquantified, bullet)
+		}
+	}
+	return metrics
+}
+
+// shortenBullet trims a bullet to a readable length for feedback
+func shortenBullet(bullet string) string {
+	const maxWords = 12
+	words := strings.Fields(bullet)
+	if len(words) <= maxWords {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words[:maxWords], " ") + "..."
+}
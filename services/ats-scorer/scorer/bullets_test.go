@@ -0,0 +1,78 @@
+package scorer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// nlpExperience is an experience section as the NLP service sends it: one resume line per
+// line (nlp-service's TestClassifySectionsKeepsLines checks it produces exactly this)
+const nlpExperience = "Senior Engineer, Acme Jan 2020 - Present\n" +
+	"• Reduced API latency by 40% by adding a Redis cache\n" +
+	"• Worked on internal tooling for the platform team\n" +
+	"• Was responsible for code reviews across three services"
+
+func TestExperienceBullets(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "bullets under a dated position",
+			content: nlpExperience,
+			want: []string{
+				"Reduced API latency by 40% by adding a Redis cache",
+				"Worked on internal tooling for the platform team",
+				"Was responsible for code reviews across three services",
+			},
+		},
+		{
+			name:    "bullet wrapped onto a second line",
+			content: "Acme 2019 - 2021\n- Migrated the billing system to Go and\ncut invoice errors by 30%\n- Led a team of 4 engineers",
+			want:    []string{"Migrated the billing system to Go and cut invoice errors by 30%", "Led a team of 4 engineers"},
+		},
+		{
+			name:    "sentences without bullet markers",
+			content: "Globex\nMar 2017 - Dec 2018\nBuilt the payments API used by the mobile apps\nOn call",
+			want:    []string{"Built the payments API used by the mobile apps"},
+		},
+		{
+			name:    "no experience",
+			content: "",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := experienceBullets(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("experienceBullets = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasQuantifiedImpact(t *testing.T) {
+	tests := []struct {
+		bullet string
+		want   bool
+	}{
+		{"Reduced API latency by 40%", true},
+		{"Cut cloud spend by $1.2M a year", true},
+		{"Made the build 3x faster", true},
+		{"Grew the platform to 2M users", true},
+		{"Reduced p99 latency from 800ms to 120ms", true},
+		{"Worked on internal tooling", false},
+		{"Acme, Jan 2020 - Present", false},
+		{"Call +1 (555) 123-4567 for references", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bullet, func(t *testing.T) {
+			if got := hasQuantifiedImpact(tt.bullet); got != tt.want {
+				t.Errorf("hasQuantifiedImpact = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
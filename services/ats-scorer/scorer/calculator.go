@@ -2,16 +2,63 @@ package scorer
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 )
 
+// yearPattern matches a four digit year
+var yearPattern = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+
+// quantityPattern matches figures such as "1st", "top 5%", "$10k" or "3x"
+var quantityPattern = regexp.MustCompile(`(?i)[$€£]?\d[\d,.]*\s*(%|k\b|m\b|x\b|st\b|nd\b|rd\b|th\b)?`)
+
+var (
+	// termPatterns caches the compiled pattern for each term containsTerm has looked up
+	termPatterns sync.Map
+	// exactTermPatterns caches the compiled pattern for each term containsExactTerm has looked up
+	exactTermPatterns sync.Map
+)
+
+// containsTerm reports whether text contains term as a whole word, ignoring case
+func containsTerm(text, term string) bool {
+	return matchTerm(&termPatterns, `(?i)`, text, term)
+}
+
+// containsExactTerm reports whether text contains term as a whole word, written exactly as given
+func containsExactTerm(text, term string) bool {
+	return matchTerm(&exactTermPatterns, ``, text, term)
+}
+
+// matchTerm looks up the pattern for term in cache, compiling it with flags on first use
+func matchTerm(cache *sync.Map, flags, text, term string) bool {
+	pattern, ok := cache.Load(term)
+	if !ok {
+		compiled := regexp.MustCompile(flags + `(^|[^\pL\pN+#])` + regexp.QuoteMeta(term) + `($|[^\pL\pN+#])`)
+		pattern, _ = cache.LoadOrStore(term, compiled)
+	}
+	return pattern.(*regexp.Regexp).MatchString(text)
+}
+
+// sectionSignals are the NLP results section evaluators use besides the section text
+type sectionSignals struct {
+	MatchedSkills      []string
+	Certifications     []string
+	CertificationTerms map[string][]string // How the resume writes each certification
+	Degrees            []Degree
+	Education          EducationMatch
+	Readability        Readability
+	Years              int // Years of dated experience
+}
+
 // calculateSectionScores evaluates each resume section
-func calculateSectionScores(sections map[string]string) map[string]SectionScore {
+func calculateSectionScores(sections map[string]string, signals sectionSignals, thresholds SectionThresholds) map[string]SectionScore {
 	scores := make(map[string]SectionScore)
 
 	// Skills section
 	if content, exists := sections["skills"]; exists && content != "" {
-		score, feedback := evaluateSkillsSection(content)
+		score, feedback := evaluateSkillsSection(content, thresholds)
 		scores["skills"] = SectionScore{Score: score, Feedback: feedback}
 	} else {
 		scores["skills"] = SectionScore{Score: 30, Feedback: "Skills section not found or empty. Add a dedicated skills section."}
@@ -19,15 +66,15 @@ func calculateSectionScores(sections map[string]string) map[string]SectionScore
 
 	// Experience section
 	if content, exists := sections["experience"]; exists && content != "" {
-		score, feedback := evaluateExperienceSection(content)
-		scores["experience"] = SectionScore{Score: score, Feedback: feedback}
+		score, feedback, details := evaluateExperienceSection(content, thresholds)
+		scores["experience"] = SectionScore{Score: score, Feedback: feedback, Details: details}
 	} else {
 		scores["experience"] = SectionScore{Score: 40, Feedback: "Experience section not clearly identified. Ensure work experience is highlighted."}
 	}
 
 	// Education section
 	if content, exists := sections["education"]; exists && content != "" {
-		score, feedback := evaluateEducationSection(content)
+		score, feedback := evaluateEducationSection(content, signals.Degrees, signals.Education, thresholds)
 		scores["education"] = SectionScore{Score: score, Feedback: feedback}
 	} else {
 		scores["education"] = SectionScore{Score: 50, Feedback: "Education section not found or brief."}
@@ -35,21 +82,43 @@ func calculateSectionScores(sections map[string]string) map[string]SectionScore
 
 	// Projects section
 	if content, exists := sections["projects"]; exists && content != "" {
-		score, feedback := evaluateProjectsSection(content)
+		score, feedback := evaluateProjectsSection(content, thresholds)
 		scores["projects"] = SectionScore{Score: score, Feedback: feedback}
 	} else {
 		scores["projects"] = SectionScore{Score: 40, Feedback: "Consider adding a projects section to showcase practical work."}
 	}
 
+	// Summary, certifications and achievements are optional, so they are only scored when present
+	if content, exists := sections["summary"]; exists && content != "" {
+		score, feedback := evaluateSummarySection(content, signals.MatchedSkills)
+		scores["summary"] = SectionScore{Score: score, Feedback: feedback}
+	}
+
+	if content, exists := sections["certifications"]; exists && content != "" {
+		score, feedback := evaluateCertificationsSection(content, signals.Certifications, signals.CertificationTerms)
+		scores["certifications"] = SectionScore{Score: score, Feedback: feedback}
+	}
+
+	if content, exists := sections["achievements"]; exists && content != "" {
+		score, feedback := evaluateAchievementsSection(content)
+		scores["achievements"] = SectionScore{Score: score, Feedback: feedback}
+	}
+
+	// Presentation covers the whole resume, so it is scored whenever the NLP service measured it
+	if signals.Readability.WordCount > 0 {
+		score, feedback, details := evaluatePresentation(signals.Readability, signals.Years)
+		scores["presentation"] = SectionScore{Score: score, Feedback: feedback, Details: details}
+	}
+
 	return scores
 }
 
-func evaluateSkillsSection(content string) (int, string) {
+func evaluateSkillsSection(content string, thresholds SectionThresholds) (int, string) {
 	wordCount := len(strings.Fields(content))
 
-	if wordCount >= 30 {
+	if wordCount >= thresholds.SkillsWords {
 		return 90, "Comprehensive skills section with good variety."
-	} else if wordCount >= 15 {
+	} else if wordCount >= thresholds.SkillsWords/2 {
 		return 75, "Good skills section. Consider adding more relevant technologies."
 	} else if wordCount >= 5 {
 		return 60, "Skills section present but could be expanded."
@@ -57,40 +126,47 @@ func evaluateSkillsSection(content string) (int, string) {
 	return 40, "Skills section is too brief. Add more details."
 }
 
-func evaluateExperienceSection(content string) (int, string) {
+func evaluateExperienceSection(content string, thresholds SectionThresholds) (int, string, []string) {
 	wordCount := len(strings.Fields(content))
 
-	// Check for action verbs (indicates good resume writing)
-	actionVerbs := []string{"developed", "implemented", "managed", "led", "created", "designed",
-		"improved", "increased", "reduced", "built", "launched", "achieved", "delivered"}
+	// Check how the bullets are written (action verbs, weak phrasing) and which show quantified impact
+	bullets := experienceBullets(content)
+	writing := analyzeWriting(bullets)
+	metrics := analyzeBulletMetrics(bullets)
+	ratio := metrics.Ratio()
 
-	actionVerbCount := 0
-	contentLower := strings.ToLower(content)
-	for _, verb := range actionVerbs {
-		if strings.Contains(contentLower, verb) {
-			actionVerbCount++
-		}
+	strongRatio := float64(0)
+	if writing.Bullets > 0 {
+		strongRatio = float64(writing.StrongOpeners) / float64(writing.Bullets)
 	}
 
-	// Check for metrics/numbers
-	hasMetrics := strings.ContainsAny(content, "0123456789%")
-
 	baseScore := 50
-	if wordCount >= 200 {
+	if wordCount >= thresholds.ExperienceWords {
 		baseScore += 20
-	} else if wordCount >= 100 {
+	} else if wordCount >= thresholds.ExperienceWords/2 {
 		baseScore += 10
 	}
 
-	if actionVerbCount >= 5 {
+	if strongRatio >= thresholds.StrongOpenerRatio {
 		baseScore += 15
-	} else if actionVerbCount >= 2 {
+	} else if strongRatio >= thresholds.StrongOpenerRatio/2 {
 		baseScore += 8
 	}
 
-	if hasMetrics {
-		baseScore += 10
+	if ratio >= thresholds.MetricRatio {
+		baseScore += 15
+	} else if ratio >= thresholds.MetricRatio/2 {
+		baseScore += 8
+	} else if metrics.Quantified > 0 {
+		baseScore += 4
+	}
+
+	// Each writing issue costs a little, up to 15 points
+	writingPenalty := 3 * writing.IssueCount()
+	if writingPenalty > 15 {
+		writingPenalty = 15
 	}
+	baseScore -= writingPenalty
 
 	if baseScore > 95 {
 		baseScore = 95
@@ -105,21 +181,93 @@ func evaluateExperienceSection(content string) (int, string) {
 		feedback = "Experience section needs improvement. Use action verbs and include metrics."
 	}
 
-	return baseScore, feedback
+	// Point at the bullets that need rewording or a number
+	feedback += writingSummary(writing)
+	details := append(make([]string, 0, len(writing.Details)+len(metrics.Unquantified)), writing.Details...)
+	if metrics.Total > 0 {
+		feedback += fmt.Sprintf(" %d of %d bullets include a measurable result.", metrics.Quantified, metrics.Total)
+		for _, bullet := range metrics.Unquantified {
+			details = append(details, fmt.Sprintf("No measurable result (percentage, amount, multiplier or count): %q", shortenBullet(bullet)))
+		}
+		if len(metrics.Unquantified) > 0 && ratio < thresholds.MetricRatio {
+			feedback += fmt.Sprintf(" Add the impact to bullets such as %q.", shortenBullet(metrics.Unquantified[0]))
+		}
+	}
+
+	return baseScore, feedback, details
 }
 
-func evaluateEducationSection(content string) (int, string) {
-	wordCount := len(strings.Fields(content))
+func evaluateEducationSection(content string, degrees []Degree, match EducationMatch, thresholds SectionThresholds) (int, string) {
+	// Without recognizable degrees, fall back to the level of detail
+	if len(degrees) == 0 {
+		wordCount := len(strings.Fields(content))
+		if wordCount >= thresholds.EducationWords {
+			return 85, "Well-detailed education section."
+		} else if wordCount >= thresholds.EducationWords*2/5 {
+			return 75, "Good education section with essential details."
+		}
+		return 60, "Education section is present but brief. Consider adding relevant coursework or achievements."
+	}
+
+	// Reward the details ATS parsers look for on each degree
+	highest := degrees[0]
+	for _, degree := range degrees {
+		if degree.Rank > highest.Rank {
+			highest = degree
+		}
+	}
+
+	baseScore := 60
+	if highest.Field != "" {
+		baseScore += 10
+	}
+	if highest.Institution != "" {
+		baseScore += 5
+	}
+	if highest.GraduationYear > 0 {
+		baseScore += 5
+	}
+
+	switch match.Status {
+	case EducationExceeded, EducationMet:
+		baseScore += 15
+		if !match.FieldMatch {
+			baseScore -= 5
+		}
+	case EducationUnmet:
+		if match.Equivalent {
+			baseScore -= 5
+		} else {
+			baseScore -= 20
+		}
+	}
+
+	if baseScore > 95 {
+		baseScore = 95
+	}
 
-	if wordCount >= 50 {
-		return 85, "Well-detailed education section."
-	} else if wordCount >= 20 {
-		return 75, "Good education section with essential details."
+	var feedback string
+	switch {
+	case match.Status == EducationUnmet && match.Equivalent:
+		feedback = fmt.Sprintf("The job asks for a %s degree or equivalent experience. Make sure your experience section shows the equivalent.", match.Required)
+	case match.Status == EducationUnmet:
+		feedback = fmt.Sprintf("The job requires a %s degree, which was not found on your resume.", match.Required)
+	case (match.Status == EducationMet || match.Status == EducationExceeded) && !match.FieldMatch:
+		feedback = fmt.Sprintf("Your degree meets the %s requirement, but not in one of the fields the job lists. Highlight relevant coursework.", match.Required)
+	case match.Status == EducationExceeded:
+		feedback = fmt.Sprintf("Your education exceeds the %s degree the job asks for.", match.Required)
+	case match.Status == EducationMet:
+		feedback = "Your education meets the job's degree requirement."
+	case highest.Field == "" || highest.Institution == "" || highest.GraduationYear == 0:
+		feedback = "List each degree with its field of study, institution and graduation year."
+	default:
+		feedback = "Well-formatted education section."
 	}
-	return 60, "Education section is present but brief. Consider adding relevant coursework or achievements."
+
+	return baseScore, feedback
 }
 
-func evaluateProjectsSection(content string) (int, string) {
+func evaluateProjectsSection(content string, thresholds SectionThresholds) (int, string) {
 	wordCount := len(strings.Fields(content))
 
 	// Check for technology mentions
@@ -133,9 +281,9 @@ func evaluateProjectsSection(content string) (int, string) {
 	}
 
 	baseScore := 50
-	if wordCount >= 100 {
+	if wordCount >= thresholds.ProjectsWords {
 		baseScore += 20
-	} else if wordCount >= 50 {
+	} else if wordCount >= thresholds.ProjectsWords/2 {
 		baseScore += 10
 	}
 
@@ -161,35 +309,211 @@ func evaluateProjectsSection(content string) (int, string) {
 	return baseScore, feedback
 }
 
-// calculateOverallScore computes weighted average of all scores
-func calculateOverallScore(skillScore, similarityScore float64, sectionScores map[string]SectionScore) int {
-	// Average section scores
-	var sectionTotal float64
-	sectionCount := 0
-	for _, section := range sectionScores {
-		sectionTotal += float64(section.Score)
-		sectionCount++
+func evaluateSummarySection(content string, matchedSkills []string) (int, string) {
+	wordCount := len(strings.Fields(content))
+
+	// A summary aligned with the JD mentions the skills the JD asks for
+	alignedCount := 0
+	for _, skill := range matchedSkills {
+		if containsTerm(content, skill) {
+			alignedCount++
+		}
+	}
+
+	baseScore := 50
+	if wordCount >= 30 && wordCount <= 100 {
+		baseScore += 20
+	} else if wordCount >= 15 && wordCount <= 150 {
+		baseScore += 10
+	}
+
+	if alignedCount >= 3 {
+		baseScore += 20
+	} else if alignedCount >= 1 {
+		baseScore += 10
+	}
+
+	var feedback string
+	if wordCount < 15 {
+		feedback = "Summary is too brief. Use 2-4 sentences on your experience and the skills the role asks for."
+	} else if wordCount > 150 {
+		feedback = "Summary is too long. Keep it to 2-4 sentences and move details to the experience section."
+	} else if alignedCount == 0 {
+		feedback = "Summary does not mention any of the job's required skills. Tailor it to the role."
+	} else if baseScore >= 80 {
+		feedback = "Concise summary aligned with the job requirements."
+	} else {
+		feedback = "Good summary. Mention more of the job's key skills to strengthen alignment."
+	}
+
+	return baseScore, feedback
+}
+
+func evaluateCertificationsSection(content string, certifications []string, terms map[string][]string) (int, string) {
+	// Certifications the NLP service recognized from its catalog that are listed in this
+	// section, by catalog name or as the resume writes them; those only mentioned elsewhere
+	// don't make the section stronger
+	recognizedCount := 0
+	for _, certification := range certifications {
+		written := containsTerm(content, certification)
+		for _, term := range terms[certification] {
+			written = written || containsTerm(content, term)
+		}
+		if written {
+			recognizedCount++
+		}
+	}
+
+	// Certifications without a year make it hard to tell whether they are current
+	hasYear := yearPattern.MatchString(content)
+
+	baseScore := 60
+	if recognizedCount >= 2 {
+		baseScore += 25
+	} else if recognizedCount == 1 {
+		baseScore += 15
+	}
+	if hasYear {
+		baseScore += 5
+	}
+
+	var feedback string
+	if recognizedCount == 0 {
+		feedback = "Certifications listed, but none are widely recognized. Use official certification names."
+	} else if !hasYear {
+		feedback = "Recognized certifications found. Add the year earned so reviewers know they are current."
+	} else {
+		feedback = "Strong certifications section with recognized, dated credentials."
+	}
+
+	return baseScore, feedback
+}
+
+func evaluateAchievementsSection(content string) (int, string) {
+	// Check for award language and quantified results
+	awardTerms := []string{"award", "winner", "won", "finalist", "ranked", "prize", "scholarship", "honor", "recognized", "top"}
+
+	awardCount := 0
+	for _, term := range awardTerms {
+		if containsTerm(content, term) {
+			awardCount++
+		}
+	}
+	quantified := 0
+	for _, quantity := range quantityPattern.FindAllString(content, -1) {
+		// A year dates the award but does not quantify it
+		if !yearPattern.MatchString(strings.TrimSpace(quantity)) {
+			quantified++
+		}
+	}
+
+	baseScore := 55
+	if awardCount >= 2 {
+		baseScore += 15
+	} else if awardCount == 1 {
+		baseScore += 8
+	}
+	if quantified >= 2 {
+		baseScore += 20
+	} else if quantified == 1 {
+		baseScore += 10
+	}
+
+	var feedback string
+	if baseScore >= 80 {
+		feedback = "Strong achievements section with quantified recognition."
+	} else if quantified == 0 {
+		feedback = "Quantify your achievements (rank, percentage, scale or amount) to make them stand out."
+	} else {
+		feedback = "Good achievements section. Name the award or ranking and what it was for."
+	}
+
+	return baseScore, feedback
+}
+
+// calculateOverallScore computes weighted average of all scores using the profile's weights,
+// explaining each component's contribution
+func calculateOverallScore(skillScore, similarityScore float64, sectionScores map[string]SectionScore, titleScore float64, titleKnown bool, profile ScoringProfile) (int, ScoreExplanation) {
+	// Average section scores, weighted by how much each section matters for the role. Float
+	// addition isn't associative, so sum in a fixed order or the truncated score can vary by run.
+	names := make([]string, 0, len(sectionScores))
+	for name := range sectionScores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sectionTotal, sectionWeights float64
+	for _, name := range names {
+		weight := profile.sectionWeight(name)
+		sectionTotal += float64(sectionScores[name].Score) * weight
+		sectionWeights += weight
 	}
 
 	avgSectionScore := float64(50)
-	if sectionCount > 0 {
-		avgSectionScore = sectionTotal / float64(sectionCount)
+	if sectionWeights > 0 {
+		avgSectionScore = sectionTotal / sectionWeights
+	}
+
+	// Weighted calculation; without a target title its weight is spread over the other components
+	weights := profile.Weights
+	components := []ComponentExplanation{
+		{Name: "skills", RawScore: skillScore, Weight: weights.Skill, Included: true},
+		{Name: "similarity", RawScore: similarityScore, Weight: weights.Similarity, Included: true},
+		{Name: "sections", RawScore: avgSectionScore, Weight: weights.Section, Included: true},
+		{Name: "title", RawScore: titleScore, Weight: weights.Title, Included: titleKnown},
+	}
+	if !titleKnown {
+		components[3].Note = "The job description names no title, so this weight is spread over the other components."
+	}
+
+	totalWeight := 0.0
+	for _, component := range components {
+		if component.Included {
+			totalWeight += component.Weight
+		}
+	}
+
+	overall := 0.0
+	for i := range components {
+		component := &components[i]
+		if component.Included && totalWeight > 0 {
+			component.EffectiveWeight = component.Weight / totalWeight
+			component.Contribution = component.RawScore * component.EffectiveWeight
+			overall += component.Contribution
+		}
+		component.RawScore = roundHundredth(component.RawScore)
+		component.EffectiveWeight = roundHundredth(component.EffectiveWeight)
+		component.Contribution = roundHundredth(component.Contribution)
 	}
 
-	// Weighted calculation
-	overall := (skillScore * SkillWeight) +
-		(similarityScore * SimilarityWeight) +
-		(avgSectionScore * SectionWeight)
+	// Work from the weighted score the explanation shows, so float error such as 62.999999
+	// for 63 isn't truncated to a lower final score
+	overall = roundHundredth(overall)
+
+	explanation := ScoreExplanation{
+		Profile:       profile.Name,
+		Components:    components,
+		Sections:      explainSections(sectionScores, profile),
+		WeightedScore: overall,
+		Adjustments:   make([]ScoreAdjustment, 0),
+	}
 
 	// Ensure within bounds
-	if overall > 100 {
-		overall = 100
+	bounded := overall
+	if bounded > 100 {
+		bounded = 100
+	}
+	if bounded < 0 {
+		bounded = 0
 	}
-	if overall < 0 {
-		overall = 0
+	if bounded != overall {
+		explanation.Adjustments = append(explanation.Adjustments, ScoreAdjustment{
+			Step: "clamp", Before: int(overall), After: int(bounded), Reason: "The weighted score is kept between 0 and 100.",
+		})
 	}
+	explanation.Final = int(bounded)
 
-	return int(overall)
+	return int(bounded), explanation
 }
 
 // generateOverallFeedback creates summary feedback based on score
@@ -0,0 +1,213 @@
+package scorer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// determinismRuns is how many times each input is scored; map iteration order changes
+// between runs, so a score that depends on it shows up as a difference
+const determinismRuns = 50
+
+func TestCalculateOverallScoreDeterministic(t *testing.T) {
+	profile, _ := LookupProfile("software_engineer")
+
+	tests := []struct {
+		name          string
+		sections      map[string]SectionScore
+		wantSection   float64 // Weighted section average
+		wantWeighted  float64
+		wantScore     int
+		wantBreakdown []string // Sections in the explanation, in order
+	}{
+		{"no sections", map[string]SectionScore{}, 50, 56.1, 56, []string{}},
+		{"one section", map[string]SectionScore{"experience": {Score: 73}}, 73, 63, 63, []string{"experience"}},
+		{
+			name: "many sections with uneven weights",
+			sections: map[string]SectionScore{
+				"experience": {Score: 71}, "education": {Score: 83}, "skills": {Score: 67},
+				"projects": {Score: 59}, "certifications": {Score: 91}, "achievements": {Score: 47},
+				"presentation": {Score: 77},
+			},
+			// 441.3 over a total section weight of 6.3
+			wantSection:   70.05,
+			wantWeighted:  62.11,
+			wantScore:     62,
+			wantBreakdown: []string{"achievements", "certifications", "education", "experience", "presentation", "projects", "skills"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < determinismRuns; i++ {
+				score, explanation := calculateOverallScore(61.3, 47.9, tt.sections, 70, true, profile)
+				if score != tt.wantScore || explanation.WeightedScore != tt.wantWeighted {
+					t.Fatalf("run %d: score %d (weighted %v), want %d (weighted %v)",
+						i, score, explanation.WeightedScore, tt.wantScore, tt.wantWeighted)
+				}
+				if got := explanation.Components[2].RawScore; got != tt.wantSection {
+					t.Fatalf("run %d: section average = %v, want %v", i, got, tt.wantSection)
+				}
+				breakdown := make([]string, 0, len(explanation.Sections))
+				for _, section := range explanation.Sections {
+					breakdown = append(breakdown, section.Section)
+				}
+				if !reflect.DeepEqual(breakdown, tt.wantBreakdown) {
+					t.Fatalf("run %d: section breakdown = %q, want %q", i, breakdown, tt.wantBreakdown)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateOverallScoreFinal(t *testing.T) {
+	profile, _ := LookupProfile("software_engineer")
+
+	tests := []struct {
+		name         string
+		sections     map[string]SectionScore
+		wantWeighted float64
+		wantFinal    int
+	}{
+		// 24.52 + 9.58 + 21.9 + 7 adds up to 62.999999 in floating point
+		{"weighted score just under a whole number", map[string]SectionScore{"experience": {Score: 73}}, 63, 63},
+		{"fractional weighted score", map[string]SectionScore{"experience": {Score: 74}}, 63.3, 63},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, explanation := calculateOverallScore(61.3, 47.9, tt.sections, 70, true, profile)
+			if explanation.WeightedScore != tt.wantWeighted {
+				t.Errorf("weighted score = %v, want %v", explanation.WeightedScore, tt.wantWeighted)
+			}
+			if score != tt.wantFinal || explanation.Final != tt.wantFinal {
+				t.Errorf("score = %d (final %d), want %d", score, explanation.Final, tt.wantFinal)
+			}
+		})
+	}
+}
+
+func TestScoreResumeDeterministic(t *testing.T) {
+	req := ScoreRequest{
+		Skills:        []string{"go", "python", "aws", "docker"},
+		MatchedSkills: []string{"go", "python", "aws"},
+		MissingSkills: []string{"kubernetes", "terraform"},
+		Sections: map[string]string{
+			"summary":    "Backend engineer with 6 years building APIs in Go and Python on AWS.",
+			"experience": "Led migration of 40 services to AWS, cutting costs 30%. Built Go APIs serving 2M users. Responsible for on-call.",
+			"education":  "BSc Computer Science, State University, 2016",
+			"skills":     "Go, Python, AWS, Docker, PostgreSQL",
+			"projects":   "Open-source rate limiter in Go with 1k stars.",
+		},
+		SectionBlocks: []SectionBlock{
+			{Name: "summary", Heading: "Summary", StartLine: 1},
+			{Name: "experience", Heading: "Experience", StartLine: 4},
+			{Name: "education", Heading: "Education", StartLine: 10},
+		},
+		SimilarityScore: 0.58,
+		ExperienceYears: 6,
+	}
+
+	// Section scores don't depend on the profile; its weights only change how they combine
+	wantSections := map[string]int{"summary": 70, "experience": 80, "education": 60, "skills": 60, "projects": 50}
+	wantScores := map[string]int{
+		"data_scientist":    44,
+		"default":           44,
+		"designer":          44,
+		"new_grad":          44,
+		"product_manager":   43,
+		"software_engineer": 48,
+	}
+
+	for _, name := range ProfileNames() {
+		t.Run(name, func(t *testing.T) {
+			profile, _ := LookupProfile(name)
+			want := scoreResume(req, profile)
+			if want.Score != wantScores[name] {
+				t.Errorf("score = %d, want %d", want.Score, wantScores[name])
+			}
+			sections := make(map[string]int, len(want.Sections))
+			for section, score := range want.Sections {
+				sections[section] = score.Score
+			}
+			if !reflect.DeepEqual(sections, wantSections) {
+				t.Errorf("section scores = %v, want %v", sections, wantSections)
+			}
+
+			for i := 0; i < determinismRuns; i++ {
+				if got := scoreResume(req, profile); !reflect.DeepEqual(got, want) {
+					t.Fatalf("run %d: score %d, first run gave %d", i, got.Score, want.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestScoreResumeNLPSections(t *testing.T) {
+	req := ScoreRequest{
+		MatchedSkills: []string{"go", "redis"},
+		MissingSkills: []string{"kubernetes"},
+		Sections:      map[string]string{"experience": nlpExperience, "skills": "Go, Redis"},
+		SectionBlocks: []SectionBlock{
+			{Name: "experience", Heading: "EXPERIENCE", Content: nlpExperience, StartLine: 3},
+			{Name: "skills", Heading: "SKILLS", Content: "Go, Redis", StartLine: 9},
+		},
+	}
+	profile, _ := LookupProfile(DefaultProfile)
+	resp := scoreResume(req, profile)
+
+	experience := resp.Sections["experience"]
+	wantDetails := []string{
+		`Weak opening "worked on": start with an action verb that shows the result: "Worked on internal tooling for the platform team"`,
+		`Weak opening "was responsible for": start with an action verb that shows the result: "Was responsible for code reviews across three services"`,
+		`No measurable result (percentage, amount, multiplier or count): "Worked on internal tooling for the platform team"`,
+		`No measurable result (percentage, amount, multiplier or count): "Was responsible for code reviews across three services"`,
+	}
+	if !reflect.DeepEqual(experience.Details, wantDetails) {
+		t.Errorf("experience details = %q, want %q", experience.Details, wantDetails)
+	}
+	if want := " 1 of 3 bullets include a measurable result."; !strings.Contains(experience.Feedback, want) {
+		t.Errorf("experience feedback = %q, want it to contain %q", experience.Feedback, want)
+	}
+
+	originals := make([]string, 0, len(resp.Rewrites))
+	for _, rewrite := range resp.Rewrites {
+		originals = append(originals, rewrite.Original)
+	}
+	wantOriginals := []string{
+		"Worked on internal tooling for the platform team",
+		"Was responsible for code reviews across three services",
+	}
+	if !reflect.DeepEqual(originals, wantOriginals) {
+		t.Errorf("rewritten bullets = %q, want %q", originals, wantOriginals)
+	}
+}
+
+func TestEvaluateCertificationsSection(t *testing.T) {
+	certifications := []string{"Certified Kubernetes Administrator", "AWS Certified Solutions Architect - Associate"}
+	terms := map[string][]string{
+		"Certified Kubernetes Administrator":            {"CKA"},
+		"AWS Certified Solutions Architect - Associate": {"AWS SAA"},
+	}
+
+	tests := []struct {
+		name      string
+		content   string
+		wantScore int
+	}{
+		{"both listed as written", "CKA, 2022\nAWS SAA, 2021", 90},
+		{"one listed by catalog name", "Certified Kubernetes Administrator, 2022", 80},
+		// The other certification is only mentioned elsewhere in the resume
+		{"one listed", "CKA, 2022\nScrum Master, 2019", 80},
+		{"none recognized", "Scrum Master, 2019", 65},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if score, _ := evaluateCertificationsSection(tt.content, certifications, terms); score != tt.wantScore {
+				t.Errorf("score = %d, want %d", score, tt.wantScore)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package scorer
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// weightTolerance is how far component weights may sum from 1 before they are normalized
+const weightTolerance = 0.001
+
+// Problems found while loading the configuration. Errors stop the service from starting;
+// warnings describe values that were corrected.
+var (
+	configErrors   []string
+	configWarnings []string
+)
+
+// ConfigErrors lists the configuration problems that should stop the service from starting
+func ConfigErrors() []string {
+	return configErrors
+}
+
+// ConfigResponse is the effective scoring configuration
+type ConfigResponse struct {
+	DefaultProfile string           `json:"defaultProfile"`
+	Profiles       []ScoringProfile `json:"profiles"`
+	RuleSets       []RuleSetConfig  `json:"ruleSets"`
+	Emulations     []Emulation      `json:"emulations"`
+	Warnings       []string         `json:"warnings"`
+}
+
+// RuleSetConfig is a loaded rule set without its tests
+type RuleSetConfig struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// HandleConfig returns the weights, profiles, rules and emulations the scorer is running with
+func HandleConfig(c *fiber.Ctx) error {
+	response := ConfigResponse{
+		DefaultProfile: DefaultProfile,
+		Profiles:       make([]ScoringProfile, 0, len(profiles)),
+		RuleSets:       make([]RuleSetConfig, 0, len(ruleSets)),
+		Emulations:     emulations,
+		Warnings:       append([]string{}, configWarnings...),
+	}
+	for _, name := range ProfileNames() {
+		response.Profiles = append(response.Profiles, profiles[name])
+	}
+	for _, set := range ruleSets {
+		response.RuleSets = append(response.RuleSets, RuleSetConfig{Name: set.Name, Rules: set.Rules})
+	}
+	return c.JSON(response)
+}
+
+// normalizeWeights checks that component weights are non-negative and not all zero, then
+// scales them to sum to 1. It reports whether the weights had to be scaled.
+func normalizeWeights(weights ComponentWeights) (ComponentWeights, bool, error) {
+	sum := 0.0
+	for _, weight := range []struct {
+		name  string
+		value float64
+	}{
+		{"skill", weights.Skill},
+		{"similarity", weights.Similarity},
+		{"section", weights.Section},
+		{"title", weights.Title},
+	} {
+		if weight.value < 0 || math.IsNaN(weight.value) || math.IsInf(weight.value, 0) {
+			return weights, false, fmt.Errorf("%s weight %v must be a non-negative number", weight.name, weight.value)
+		}
+		sum += weight.value
+	}
+	if sum == 0 {
+		return weights, false, fmt.Errorf("weights are all zero")
+	}
+	if math.Abs(sum-1) <= weightTolerance {
+		return weights, false, nil
+	}
+
+	return ComponentWeights{
+		Skill:      weights.Skill / sum,
+		Similarity: weights.Similarity / sum,
+		Section:    weights.Section / sum,
+		Title:      weights.Title / sum,
+	}, true, nil
+}
+
+// validateProfile checks a profile's weights, thresholds and knockout criteria, normalizing its component
+// weights when they don't sum to 1. It returns one message per problem.
+func validateProfile(profile *ScoringProfile) []string {
+	var errs []string
+
+	weights, scaled, err := normalizeWeights(profile.Weights)
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else if scaled {
+		w := profile.Weights
+		warning := fmt.Sprintf("profile %q: weights sum to %.3f, normalized to skill %.3f, similarity %.3f, section %.3f, title %.3f",
+			profile.Name, w.Skill+w.Similarity+w.Section+w.Title, weights.Skill, weights.Similarity, weights.Section, weights.Title)
+		log.Printf("Warning: %s", warning)
+		configWarnings = append(configWarnings, warning)
+		profile.Weights = weights
+	}
+
+	known := make(map[string]bool)
+	for _, section := range scoredSections {
+		known[section] = true
+	}
+	for section, weight := range profile.SectionWeights {
+		if !known[section] {
+			errs = append(errs, fmt.Sprintf("unknown section %q in section weights", section))
+		}
+		if weight < 0 {
+			errs = append(errs, fmt.Sprintf("section weight for %q must not be negative", section))
+		}
+	}
+
+	t := profile.Thresholds
+	for name, words := range map[string]int{
+		"skillsWords": t.SkillsWords, "experienceWords": t.ExperienceWords,
+		"projectsWords": t.ProjectsWords, "educationWords": t.EducationWords,
+	} {
+		if words < 0 {
+			errs = append(errs, fmt.Sprintf("threshold %s must not be negative", name))
+		}
+	}
+	for name, share := range map[string]float64{"strongOpenerRatio": t.StrongOpenerRatio, "metricRatio": t.MetricRatio} {
+		if share < 0 || share > 1 {
+			errs = append(errs, fmt.Sprintf("threshold %s must be between 0 and 1", name))
+		}
+	}
+
+	if profile.Knockouts.YearsGrace < 0 {
+		errs = append(errs, "knockout yearsGrace must not be negative")
+	}
+
+	sort.Strings(errs)
+	return errs
+}
@@ -0,0 +1,97 @@
+package scorer
+
+import (
+	"strings"
+)
+
+// Education requirement statuses
+const (
+	EducationExceeded    = "exceeded"
+	EducationMet         = "met"
+	EducationUnmet       = "unmet"
+	EducationNotRequired = "not_required"
+)
+
+// Degree mirrors a resume degree from the NLP service
+type Degree struct {
+	Level          string  `json:"level"`
+	Rank           int     `json:"rank"`
+	Field          string  `json:"field"`
+	Institution    string  `json:"institution"`
+	GraduationYear int     `json:"graduationYear"`
+	GPA            float64 `json:"gpa"`
+	GPAScale       float64 `json:"gpaScale"`
+}
+
+// EducationRequirement mirrors the JD degree requirement from the NLP service
+type EducationRequirement struct {
+	Level      string   `json:"level"`
+	Rank       int      `json:"rank"`
+	Fields     []string `json:"fields"`
+	Equivalent bool     `json:"equivalent"`
+	Required   bool     `json:"required"`
+}
+
+// EducationMatch reports how the resume's degrees compare with the JD requirement
+type EducationMatch struct {
+	Status     string `json:"status"`
+	Required   string `json:"required,omitempty"` // Degree level the JD asks for
+	Highest    string `json:"highest,omitempty"`  // Highest degree level in the resume
+	FieldMatch bool   `json:"fieldMatch"`         // A qualifying degree is in one of the JD's fields
+	Equivalent bool   `json:"equivalent"`         // The JD accepts equivalent experience instead
+}
+
+// matchEducation compares the highest resume degree with the JD requirement
+func matchEducation(degrees []Degree, requirement *EducationRequirement) EducationMatch {
+	var highest Degree
+	for _, degree := range degrees {
+		if degree.Rank > highest.Rank {
+			highest = degree
+		}
+	}
+
+	if requirement == nil || requirement.Rank == 0 {
+		return EducationMatch{Status: EducationNotRequired, Highest: highest.Level, FieldMatch: true}
+	}
+
+	match := EducationMatch{
+		Required:   requirement.Level,
+		Highest:    highest.Level,
+		Equivalent: requirement.Equivalent,
+	}
+
+	switch {
+	case highest.Rank > requirement.Rank:
+		match.Status = EducationExceeded
+	case highest.Rank == requirement.Rank:
+		match.Status = EducationMet
+	default:
+		match.Status = EducationUnmet
+	}
+
+	// Any qualifying degree in an accepted field counts
+	match.FieldMatch = len(requirement.Fields) == 0
+	for _, degree := range degrees {
+		if degree.Rank >= requirement.Rank && fieldMatches(degree.Field, requirement.Fields) {
+			match.FieldMatch = true
+			break
+		}
+	}
+
+	return match
+}
+
+// fieldMatches reports whether a degree field is one of the accepted fields
+func fieldMatches(field string, accepted []string) bool {
+	field = strings.ToLower(field)
+	if field == "" {
+		return false
+	}
+	for _, candidate := range accepted {
+		candidate = strings.ToLower(candidate)
+		if strings.Contains(field, candidate) || strings.Contains(candidate, field) {
+			return true
+		}
+	}
+	return false
+}
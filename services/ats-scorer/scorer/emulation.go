@@ -0,0 +1,248 @@
+package scorer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+)
+
+// StandardEmulation scores the way this service normally does
+const StandardEmulation = "standard"
+
+// Emulation reproduces how a kind of commercial ATS parses and ranks resumes
+type Emulation struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Weights     *ComponentWeights `json:"weights,omitempty"` // Replace the profile's weights; nil keeps them
+	// ExactKeywords keeps a matched skill only when the resume has the JD's term written the
+	// same way, case included ("JavaScript", not "javascript"), wherever it appears
+	ExactKeywords bool `json:"exactKeywords"`
+	// StrictHeadings keeps only sections under a heading from the language files, ignoring
+	// case, punctuation and spacing; content under other headings is lost
+	StrictHeadings bool `json:"strictHeadings"`
+	// LegacyParsing loses the text an older parser can't read, such as tables and text boxes
+	LegacyParsing bool `json:"legacyParsing"`
+}
+
+// emulations are the available modes, in the order they are reported
+var emulations = []Emulation{
+	{
+		Name:        StandardEmulation,
+		Description: "This service's own scoring: context similarity and flexible headings",
+	},
+	{
+		Name:          "strict-keyword",
+		Description:   "Case-sensitive exact keyword matching with no credit for context similarity",
+		Weights:       &ComponentWeights{Skill: 0.55, Similarity: 0, Section: 0.35, Title: 0.10},
+		ExactKeywords: true,
+	},
+	{
+		Name:        "semantic",
+		Description: "Semantic ranking that weighs overall similarity to the job description over keyword counts",
+		Weights:     &ComponentWeights{Skill: 0.25, Similarity: 0.45, Section: 0.20, Title: 0.10},
+	},
+	{
+		Name:           "legacy-parser",
+		Description:    "An older parser that skips tables and text boxes and only knows standard headings",
+		Weights:        &ComponentWeights{Skill: 0.45, Similarity: 0.15, Section: 0.30, Title: 0.10},
+		StrictHeadings: true,
+		LegacyParsing:  true,
+	},
+}
+
+// standardHeadings maps each heading in the built-in language files, normalized, to its section
+var standardHeadings = loadStandardHeadings()
+
+// loadStandardHeadings reads the section headings of every built-in language. The first
+// section listing a heading keeps it, in language code and section name order.
+func loadStandardHeadings() map[string]string {
+	headings := make(map[string]string)
+
+	files := sharedlang.LoadFiles("")
+	codes := make([]string, 0, len(files))
+	for code := range files {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		lang := files[code]
+		sections := make([]string, 0, len(lang.SectionHeadings))
+		for section := range lang.SectionHeadings {
+			sections = append(sections, section)
+		}
+		sort.Strings(sections)
+		for _, section := range sections {
+			for _, heading := range lang.SectionHeadings[section] {
+				if name := normalizeHeading(heading); name != "" && headings[name] == "" {
+					headings[name] = section
+				}
+			}
+		}
+	}
+
+	return headings
+}
+
+// EmulationScore is the score one emulated ATS would give
+type EmulationScore struct {
+	Mode         string   `json:"mode"`
+	Description  string   `json:"description"`
+	Score        int      `json:"score"`
+	KnockedOut   bool     `json:"knockedOut"`
+	LostSkills   []string `json:"lostSkills"`   // Matched skills this ATS would not find
+	LostSections []string `json:"lostSections"` // Sections this ATS would not recognize
+}
+
+// LookupEmulation returns the named emulation, or the standard one for an empty name
+func LookupEmulation(name string) (Emulation, bool) {
+	if name == "" {
+		name = StandardEmulation
+	}
+	for _, emulation := range emulations {
+		if strings.EqualFold(emulation.Name, name) {
+			return emulation, true
+		}
+	}
+	return Emulation{}, false
+}
+
+// EmulationNames lists the available emulations in the order they are reported
+func EmulationNames() []string {
+	names := make([]string, 0, len(emulations))
+	for _, emulation := range emulations {
+		names = append(names, emulation.Name)
+	}
+	return names
+}
+
+// emulate rewrites a scoring request and profile to what the emulated ATS would see.
+// It returns the matched skills and sections the ATS would lose.
+func emulate(req ScoreRequest, profile ScoringProfile, emulation Emulation) (ScoreRequest, ScoringProfile, []string, []string) {
+	if emulation.Weights != nil {
+		profile.Weights = *emulation.Weights
+	}
+
+	// Copy what is rewritten so the caller's request is left alone
+	sections := make(map[string]string, len(req.Sections))
+	for name, content := range req.Sections {
+		sections[name] = content
+	}
+	blocks := append([]SectionBlock(nil), req.SectionBlocks...)
+
+	if emulation.LegacyParsing {
+		for name, content := range sections {
+			sections[name] = removeUnread(content, req.LegacyUnread)
+		}
+		for i := range blocks {
+			blocks[i].Content = removeUnread(blocks[i].Content, req.LegacyUnread)
+		}
+	}
+
+	// Rebuild sections from the blocks whose heading is standard
+	if emulation.StrictHeadings && len(blocks) > 0 {
+		sections = make(map[string]string)
+		for _, block := range blocks {
+			name, ok := standardHeadings[normalizeHeading(block.Heading)]
+			if !ok || strings.TrimSpace(block.Content) == "" {
+				continue
+			}
+			if existing, ok := sections[name]; ok {
+				sections[name] = existing + "\n" + block.Content
+			} else {
+				sections[name] = block.Content
+			}
+		}
+	}
+
+	lostSections := make([]string, 0)
+	for name, content := range req.Sections {
+		if strings.TrimSpace(content) != "" && strings.TrimSpace(sections[name]) == "" && name != unknownSection {
+			lostSections = append(lostSections, name)
+		}
+	}
+	sort.Strings(lostSections)
+
+	// Matched skills must still be found in the text the ATS kept, written as in the JD when
+	// it matches keywords exactly
+	lostSkills := make([]string, 0)
+	if emulation.ExactKeywords || emulation.LegacyParsing || emulation.StrictHeadings {
+		var text strings.Builder
+		for name, content := range sections {
+			if name == unknownSection && (emulation.LegacyParsing || emulation.StrictHeadings) {
+				continue
+			}
+			text.WriteString(content)
+			text.WriteString("\n")
+		}
+		matched := make([]string, 0, len(req.MatchedSkills))
+		missing := append([]string(nil), req.MissingSkills...)
+		for _, skill := range req.MatchedSkills {
+			found := containsTerm(text.String(), skill)
+			if emulation.ExactKeywords {
+				term := skill
+				if jdTerm := req.JDSkillTerms[skill]; jdTerm != "" {
+					term = jdTerm
+				}
+				found = containsExactTerm(text.String(), term)
+			}
+			if found {
+				matched = append(matched, skill)
+			} else {
+				missing = append(missing, skill)
+				lostSkills = append(lostSkills, skill)
+			}
+		}
+		req.MatchedSkills = matched
+		req.MissingSkills = missing
+	}
+
+	req.Sections = sections
+	req.SectionBlocks = blocks
+	return req, profile, lostSkills, lostSections
+}
+
+// removeUnread removes the text a legacy parser skips from section content. Unread text may
+// wrap across lines, so any run of whitespace matches; the lines left keep their breaks.
+func removeUnread(content string, unread []string) string {
+	if len(unread) == 0 {
+		return content
+	}
+	for _, text := range unread {
+		if pattern := wordsPattern(text); pattern != nil {
+			content = pattern.ReplaceAllString(content, "")
+		}
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wordsPattern matches the words of text separated by any whitespace, or is nil for blank text
+func wordsPattern(text string) *regexp.Regexp {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return regexp.MustCompile(strings.Join(words, `\s+`))
+}
+
+// normalizeHeading lower-cases a heading and drops punctuation, so "WORK EXPERIENCE:" reads
+// as "work experience"
+func normalizeHeading(heading string) string {
+	heading = strings.ReplaceAll(strings.ToLower(heading), "&", " and ")
+	return strings.Join(strings.FieldsFunc(heading, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}), " ")
+}
@@ -0,0 +1,125 @@
+package scorer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEmulateStrictHeadings(t *testing.T) {
+	emulation, _ := LookupEmulation("legacy-parser")
+
+	tests := []struct {
+		name         string
+		blocks       []SectionBlock
+		wantSections []string
+		wantSkills   []string
+	}{
+		{
+			name: "English headings",
+			blocks: []SectionBlock{
+				{Name: "experience", Heading: "WORK EXPERIENCE:", Content: "Built Go services"},
+				{Name: "skills", Heading: "Technical Skills", Content: "Go, Docker"},
+			},
+			wantSections: []string{},
+			wantSkills:   []string{},
+		},
+		{
+			name: "Spanish headings",
+			blocks: []SectionBlock{
+				{Name: "experience", Heading: "Experiencia", Content: "Built Go services"},
+				{Name: "skills", Heading: "Habilidades", Content: "Go, Docker"},
+			},
+			wantSections: []string{},
+			wantSkills:   []string{},
+		},
+		{
+			name: "skills under an unrecognized heading",
+			blocks: []SectionBlock{
+				{Name: "experience", Heading: "Experience", Content: "Built Go services"},
+				{Name: "skills", Heading: "My Toolbox", Content: "Go, Docker"},
+			},
+			wantSections: []string{"skills"},
+			wantSkills:   []string{"docker"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ScoreRequest{
+				Sections:      map[string]string{},
+				SectionBlocks: tt.blocks,
+				MatchedSkills: []string{"go", "docker"},
+			}
+			for _, block := range tt.blocks {
+				req.Sections[block.Name] = block.Content
+			}
+
+			_, _, lostSkills, lostSections := emulate(req, ScoringProfile{}, emulation)
+			if !reflect.DeepEqual(lostSections, tt.wantSections) {
+				t.Errorf("lost sections = %q, want %q", lostSections, tt.wantSections)
+			}
+			if !reflect.DeepEqual(lostSkills, tt.wantSkills) {
+				t.Errorf("lost skills = %q, want %q", lostSkills, tt.wantSkills)
+			}
+		})
+	}
+}
+
+func TestEmulateExactKeywords(t *testing.T) {
+	emulation, _ := LookupEmulation("strict-keyword")
+
+	tests := []struct {
+		name        string
+		content     string
+		terms       map[string]string
+		wantMatched []string
+		wantLost    []string
+	}{
+		{
+			name:        "written as in the JD",
+			content:     "Built JavaScript dashboards on Kubernetes",
+			terms:       map[string]string{"javascript": "JavaScript", "kubernetes": "Kubernetes"},
+			wantMatched: []string{"javascript", "kubernetes"},
+			wantLost:    []string{},
+		},
+		{
+			name:        "different case",
+			content:     "Built javascript dashboards on Kubernetes",
+			terms:       map[string]string{"javascript": "JavaScript", "kubernetes": "Kubernetes"},
+			wantMatched: []string{"kubernetes"},
+			wantLost:    []string{"javascript"},
+		},
+		{
+			name:        "no JD term falls back to the skill",
+			content:     "Built javascript dashboards on Kubernetes",
+			terms:       map[string]string{},
+			wantMatched: []string{"javascript"},
+			wantLost:    []string{"kubernetes"},
+		},
+		{
+			name:        "part of a longer word",
+			content:     "Built JavaScriptCore bindings on Kubernetes",
+			terms:       map[string]string{"javascript": "JavaScript", "kubernetes": "Kubernetes"},
+			wantMatched: []string{"kubernetes"},
+			wantLost:    []string{"javascript"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ScoreRequest{
+				Sections:      map[string]string{"experience": tt.content},
+				MatchedSkills: []string{"javascript", "kubernetes"},
+				JDSkillTerms:  tt.terms,
+			}
+
+			got, _, lostSkills, _ := emulate(req, ScoringProfile{}, emulation)
+			if !reflect.DeepEqual(got.MatchedSkills, tt.wantMatched) {
+				t.Errorf("matched skills = %q, want %q", got.MatchedSkills, tt.wantMatched)
+			}
+			if !reflect.DeepEqual(lostSkills, tt.wantLost) {
+				t.Errorf("lost skills = %q, want %q", lostSkills, tt.wantLost)
+			}
+		})
+	}
+}
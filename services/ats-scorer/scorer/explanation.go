@@ -0,0 +1,66 @@
+package scorer
+
+import (
+	"math"
+	"sort"
+)
+
+// ScoreExplanation shows how the overall score was put together
+type ScoreExplanation struct {
+	Profile       string                 `json:"profile"`
+	Components    []ComponentExplanation `json:"components"`
+	Sections      []SectionExplanation   `json:"sections"`      // How the section average was formed
+	WeightedScore float64                `json:"weightedScore"` // Sum of component contributions
+	Rules         []AppliedRule          `json:"rules"`         // Every rule that fired, including section rules
+	Adjustments   []ScoreAdjustment      `json:"adjustments"`   // Steps from the weighted score to the final score
+	Final         int                    `json:"final"`
+}
+
+// ComponentExplanation is one part of the weighted score
+type ComponentExplanation struct {
+	Name            string  `json:"name"`
+	RawScore        float64 `json:"rawScore"`        // 0-100
+	Weight          float64 `json:"weight"`          // Weight in the profile
+	EffectiveWeight float64 `json:"effectiveWeight"` // Weight after scaling the included weights to sum to 1
+	Contribution    float64 `json:"contribution"`    // RawScore × EffectiveWeight
+	Included        bool    `json:"included"`
+	Note            string  `json:"note,omitempty"`
+}
+
+// SectionExplanation is one section's share of the section average
+type SectionExplanation struct {
+	Section string  `json:"section"`
+	Score   int     `json:"score"`
+	Weight  float64 `json:"weight"` // Weight in the profile's section average
+}
+
+// ScoreAdjustment is a step that changed the score after weighting
+type ScoreAdjustment struct {
+	Step   string `json:"step"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Reason string `json:"reason"`
+}
+
+// adjust records a step that moved the score, skipping steps that changed nothing
+func (e *ScoreExplanation) adjust(step string, before, after int, reason string) {
+	if before != after {
+		e.Adjustments = append(e.Adjustments, ScoreAdjustment{Step: step, Before: before, After: after, Reason: reason})
+	}
+	e.Final = after
+}
+
+// explainSections lists the section scores and weights behind the section average, by name
+func explainSections(sectionScores map[string]SectionScore, profile ScoringProfile) []SectionExplanation {
+	sections := make([]SectionExplanation, 0, len(sectionScores))
+	for name, section := range sectionScores {
+		sections = append(sections, SectionExplanation{Section: name, Score: section.Score, Weight: profile.sectionWeight(name)})
+	}
+	sort.Slice(sections, func(i, j int) bool { return sections[i].Section < sections[j].Section })
+	return sections
+}
+
+// roundHundredth rounds an explanation figure to two decimal places
+func roundHundredth(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,98 @@
+package scorer
+
+import (
+	"strings"
+)
+
+// scoredSections are the sections rules can read features of and adjust
+var scoredSections = []string{"skills", "experience", "education", "projects", "summary", "certifications", "achievements", "presentation"}
+
+// Features are the values scoring rules can test, keyed by dotted name such as
+// "experience.metricRatio". Values are float64, bool, string or []string.
+type Features map[string]interface{}
+
+// extractFeatures collects the features of one scoring request after sections are scored
+func extractFeatures(req ScoreRequest, profile ScoringProfile, sectionScores map[string]SectionScore, education EducationMatch) Features {
+	features := Features{
+		"profile":         profile.Name,
+		"similarity":      req.SimilarityScore,
+		"experienceYears": float64(req.ExperienceYears),
+
+		"skills.matched":     float64(len(req.MatchedSkills)),
+		"skills.missing":     float64(len(req.MissingSkills)),
+		"skills.matchRatio":  ratio(len(req.MatchedSkills), len(req.MatchedSkills)+len(req.MissingSkills)),
+		"skills.missingList": lowerAll(req.MissingSkills),
+
+		"certifications.count":       float64(len(req.Certifications)),
+		"certifications.missing":     float64(len(req.MissingCertifications)),
+		"certifications.missingList": lowerAll(req.MissingCertifications),
+
+		"education.status":     education.Status,
+		"education.fieldMatch": education.FieldMatch,
+		"education.equivalent": education.Equivalent,
+		"education.degrees":    float64(len(req.Degrees)),
+
+		"title.known":        req.TitleAlignment.TargetTitle != "",
+		"title.similarity":   req.TitleAlignment.Similarity,
+		"title.seniorityGap": float64(req.TitleAlignment.SeniorityGap),
+
+		"readability.wordCount":       float64(req.Readability.WordCount),
+		"readability.pages":           req.Readability.EstimatedPages,
+		"readability.grade":           req.Readability.FleschKincaidGrade,
+		"readability.bulletDensity":   req.Readability.BulletDensity,
+		"readability.avgBulletLength": req.Readability.AvgBulletLength,
+
+		"penalties.repeatedTerms":   float64(len(req.Stuffing.RepeatedTerms)),
+		"penalties.copiedWordRatio": req.Stuffing.CopiedWordRatio,
+		"penalties.hiddenText":      float64(len(req.HiddenText)),
+	}
+
+	for _, name := range scoredSections {
+		content := req.Sections[name]
+		score, scored := sectionScores[name]
+		features["sections."+name+".present"] = strings.TrimSpace(content) != ""
+		features["sections."+name+".wordCount"] = float64(len(strings.Fields(content)))
+		features["sections."+name+".score"] = float64(score.Score)
+		features["sections."+name+".scored"] = scored
+	}
+
+	// Bullet-level writing features of the experience section
+	bullets := experienceBullets(req.Sections["experience"])
+	writing := analyzeWriting(bullets)
+	metrics := analyzeBulletMetrics(bullets)
+	features["experience.bullets"] = float64(len(bullets))
+	features["experience.strongOpenerRatio"] = ratio(writing.StrongOpeners, writing.Bullets)
+	features["experience.metricRatio"] = metrics.Ratio()
+	features["experience.weakOpeners"] = float64(writing.WeakOpeners)
+	features["experience.passiveVoice"] = float64(writing.PassiveVoice)
+	features["experience.pronouns"] = float64(writing.Pronouns)
+	features["experience.repeatedOpeners"] = float64(writing.RepeatedOpeners)
+
+	return features
+}
+
+// featureNames lists every feature a rule can refer to
+func featureNames() map[string]bool {
+	names := make(map[string]bool)
+	for name := range extractFeatures(ScoreRequest{}, ScoringProfile{}, nil, EducationMatch{}) {
+		names[name] = true
+	}
+	return names
+}
+
+// ratio divides part by total, returning 0 for an empty total
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// lowerAll lower-cases each term so rules can match skills regardless of case
+func lowerAll(terms []string) []string {
+	lowered := make([]string, len(terms))
+	for i, term := range terms {
+		lowered[i] = strings.ToLower(term)
+	}
+	return lowered
+}
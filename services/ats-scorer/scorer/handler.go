@@ -1,29 +1,66 @@
 package scorer
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 )
 
 // ScoreRequest represents the scoring request from NLP service
 type ScoreRequest struct {
-	Skills          []string          `json:"skills"`
-	MatchedSkills   []string          `json:"matchedSkills"`
-	MissingSkills   []string          `json:"missingSkills"`
-	Sections        map[string]string `json:"sections"`
-	SimilarityScore float64           `json:"similarityScore"`
+	Skills                []string              `json:"skills"`
+	MatchedSkills         []string              `json:"matchedSkills"`
+	MissingSkills         []string              `json:"missingSkills"`
+	JDSkillTerms          map[string]string     `json:"jdSkillTerms"` // Each JD skill as the JD writes it
+	Certifications        []string              `json:"certifications"`
+	CertificationTerms    map[string][]string   `json:"certificationTerms"` // How the resume writes each certification
+	MatchedCertifications []string              `json:"matchedCertifications"`
+	MissingCertifications []string              `json:"missingCertifications"`
+	Degrees               []Degree              `json:"degrees"`
+	EducationRequirement  *EducationRequirement `json:"educationRequirement"`
+	JobRequirements       JobRequirements       `json:"jobRequirements"`
+	WorkAuthorization     string                `json:"workAuthorization"` // authorized, sponsorship or unknown
+	Sections              map[string]string     `json:"sections"`          // Section content, one resume line per line
+	SectionBlocks         []SectionBlock        `json:"sectionBlocks"`
+	SimilarityScore       float64               `json:"similarityScore"`
+	Stuffing              KeywordStuffing       `json:"stuffing"`
+	HiddenText            []string              `json:"hiddenText"`
+	SkillProfiles         []SkillProfile        `json:"skillProfiles"`
+	RequiresCurrentUse    bool                  `json:"requiresCurrentUse"`
+	ReferenceYear         int                   `json:"referenceYear"` // Year skill recency is measured from; the current year when 0
+	TitleAlignment        TitleAlignment        `json:"titleAlignment"`
+	Profile               string                `json:"profile"`           // Scoring profile name; empty for the default
+	Emulation             string                `json:"emulation"`         // ATS emulation mode; empty for standard
+	CompareEmulations     bool                  `json:"compareEmulations"` // Also score under every emulation mode
+	LegacyUnread          []string              `json:"legacyUnread"`      // Text a legacy parser skips, from the parser
+	ExperienceYears       int                   `json:"experienceYears"`
+	Readability           Readability           `json:"readability"`
 }
 
 // SectionScore represents individual section scoring
 type SectionScore struct {
-	Score    int    `json:"score"`
-	Feedback string `json:"feedback"`
+	Score    int      `json:"score"`
+	Feedback string   `json:"feedback"`
+	Details  []string `json:"details,omitempty"` // Line-specific suggestions
 }
 
 // ScoreResponse represents the scoring result
 type ScoreResponse struct {
 	Score           int                     `json:"score"`
+	Profile         string                  `json:"profile"`
+	KnockedOut      bool                    `json:"knockedOut"` // An ATS would reject the resume before scoring
+	Knockouts       []Knockout              `json:"knockouts"`
 	Sections        map[string]SectionScore `json:"sections"`
 	OverallFeedback string                  `json:"overallFeedback"`
+	Education       EducationMatch          `json:"education"`
+	Rewrites        []BulletRewrite         `json:"rewrites"`
+	Explanation     ScoreExplanation        `json:"explanation"`
+	AppliedRules    []AppliedRule           `json:"appliedRules"`
+	Warnings        []string                `json:"warnings"`
+	Emulation       string                  `json:"emulation"`
+	Emulations      []EmulationScore        `json:"emulations,omitempty"` // Set when compareEmulations is requested
 	Error           string                  `json:"error,omitempty"`
 }
 
@@ -36,23 +73,139 @@ func HandleScore(c *fiber.Ctx) error {
 		})
 	}
 
-	// Calculate skill match score
-	skillScore := calculateSkillScore(req.MatchedSkills, req.MissingSkills)
+	profile, ok := LookupProfile(req.Profile)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(ScoreResponse{
+			Error: fmt.Sprintf("Unknown scoring profile %q. Available profiles: %s", req.Profile, strings.Join(ProfileNames(), ", ")),
+		})
+	}
+
+	emulation, ok := LookupEmulation(req.Emulation)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(ScoreResponse{
+			Error: fmt.Sprintf("Unknown emulation %q. Available emulations: %s", req.Emulation, strings.Join(EmulationNames(), ", ")),
+		})
+	}
+
+	// Scoring depends only on the request, so fix the year recency is measured from here
+	if req.ReferenceYear == 0 {
+		req.ReferenceYear = time.Now().Year()
+	}
+
+	emulatedReq, emulatedProfile, _, _ := emulate(req, profile, emulation)
+	response := scoreResume(emulatedReq, emulatedProfile)
+	response.Emulation = emulation.Name
+
+	// Score the resume as every emulated ATS would
+	if req.CompareEmulations {
+		response.Emulations = make([]EmulationScore, 0, len(emulations))
+		for _, mode := range emulations {
+			modeReq, modeProfile, lostSkills, lostSections := emulate(req, profile, mode)
+			result := scoreResume(modeReq, modeProfile)
+			response.Emulations = append(response.Emulations, EmulationScore{
+				Mode:         mode.Name,
+				Description:  mode.Description,
+				Score:        result.Score,
+				KnockedOut:   result.KnockedOut,
+				LostSkills:   lostSkills,
+				LostSections: lostSections,
+			})
+		}
+	}
+
+	return c.JSON(response)
+}
 
-	// Calculate section scores
-	sectionScores := calculateSectionScores(req.Sections)
+// scoreResume scores a request with a profile. It depends only on its arguments, so
+// emulations can score variations of the same request.
+func scoreResume(req ScoreRequest, profile ScoringProfile) ScoreResponse {
+	// Calculate skill match score, weighted by recency when the JD asks for current use
+	skillScore, staleSkills := calculateRecencyWeightedSkillScore(req.MatchedSkills, req.MissingSkills, req.SkillProfiles, req.RequiresCurrentUse, req.ReferenceYear)
 
-	// Calculate overall score (weighted)
-	overallScore := calculateOverallScore(skillScore, req.SimilarityScore, sectionScores)
+	// Compare education with the JD requirement, then calculate section scores
+	education := matchEducation(req.Degrees, req.EducationRequirement)
+	sectionScores := calculateSectionScores(req.Sections, sectionSignals{
+		MatchedSkills:      req.MatchedSkills,
+		Certifications:     req.Certifications,
+		CertificationTerms: req.CertificationTerms,
+		Degrees:            req.Degrees,
+		Education:          education,
+		Readability:        req.Readability,
+		Years:              req.ExperienceYears,
+	}, profile.Thresholds)
+
+	// Apply the declarative scoring rules to the extracted features
+	appliedRules, ruleDelta := applyRules(extractFeatures(req, profile, sectionScores, education), sectionScores)
+
+	// Suggest rewrites for weak experience bullets, working in the JD's missing keywords
+	rewrites := suggestRewrites(req.Sections["experience"], req.MissingSkills)
+
+	// Calculate overall score (weighted), including title fit when the JD names a title
+	titleScore, titleKnown := calculateTitleScore(req.TitleAlignment)
+	overallScore, explanation := calculateOverallScore(skillScore, req.SimilarityScore, sectionScores, titleScore, titleKnown, profile)
+	explanation.Rules = appliedRules
+	if ruleDelta != 0 {
+		explanation.adjust("rules", overallScore, clampScore(overallScore+ruleDelta),
+			fmt.Sprintf("Rules targeting the overall score changed it by %+d, kept between 0 and 100.", ruleDelta))
+		overallScore = explanation.Final
+	}
+
+	// Penalize keyword stuffing, copied JD text and hidden text
+	penalty, warnings := calculateGamingPenalty(req.Stuffing, req.HiddenText)
+	warnings = append(warnings, checkSectionStructure(req.SectionBlocks)...)
+	if penalty > 0 {
+		explanation.adjust("gaming_penalty", overallScore, clampScore(overallScore-penalty),
+			fmt.Sprintf("%d points were deducted for keyword stuffing, copied job description text or hidden text.", penalty))
+		overallScore = explanation.Final
+	}
+
+	// Screen against the JD's hard requirements; knockouts leave the score unchanged
+	knockouts, knockoutWarnings := evaluateKnockouts(req, education, profile.Knockouts)
+	warnings = append(warnings, knockoutWarnings...)
 
 	// Generate feedback
 	feedback := generateOverallFeedback(overallScore, skillScore, len(req.MissingSkills))
+	if len(staleSkills) > 0 {
+		feedback += fmt.Sprintf(" The role asks for current hands-on experience, but %s %s not used recently; highlight any recent use.",
+			strings.Join(staleSkills, ", "), pluralVerb(len(staleSkills)))
+	}
+	if len(req.MissingCertifications) > 0 {
+		feedback += fmt.Sprintf(" The job asks for certifications not found on your resume: %s. List any you hold by their official names.",
+			quoteList(req.MissingCertifications))
+	}
+	feedback += titleFeedback(req.TitleAlignment)
+	for _, rule := range appliedRules {
+		if rule.Target == OverallTarget && rule.Feedback != "" {
+			feedback += " " + rule.Feedback
+		}
+	}
+	if penalty > 0 {
+		feedback = fmt.Sprintf("Warning: %d points were deducted for attempts to game ATS keyword matching. %s", penalty, feedback)
+	}
+	if len(knockouts) > 0 {
+		feedback = fmt.Sprintf("An ATS with knockout screening would likely reject this application before scoring it, regardless of keyword match (%d failed %s). %s",
+			len(knockouts), pluralNoun(len(knockouts), "requirement"), feedback)
+	}
 
-	response := ScoreResponse{
+	return ScoreResponse{
 		Score:           overallScore,
+		Profile:         profile.Name,
+		KnockedOut:      len(knockouts) > 0,
+		Knockouts:       knockouts,
 		Sections:        sectionScores,
 		OverallFeedback: feedback,
+		Education:       education,
+		Rewrites:        rewrites,
+		Explanation:     explanation,
+		AppliedRules:    appliedRules,
+		Warnings:        warnings,
 	}
+}
 
-	return c.JSON(response)
+// pluralVerb returns "was" or "were" to agree with count
+func pluralVerb(count int) string {
+	if count == 1 {
+		return "was"
+	}
+	return "were"
 }
@@ -0,0 +1,156 @@
+package scorer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Knockout criteria
+const (
+	KnockoutCertification     = "certification"
+	KnockoutWorkAuthorization = "work_authorization"
+	KnockoutDegree            = "degree"
+	KnockoutMinimumYears      = "minimum_years"
+)
+
+// Work authorization statements reported by the NLP service
+const (
+	AuthorizationStated     = "authorized"
+	AuthorizationSponsoring = "sponsorship"
+)
+
+// JobRequirements mirrors the JD's hard requirements from the NLP service
+type JobRequirements struct {
+	MinimumYears           int        `json:"minimumYears"`
+	MinimumYearsText       string     `json:"minimumYearsText"`
+	WorkAuthorization      bool       `json:"workAuthorization"`
+	NoSponsorship          bool       `json:"noSponsorship"`
+	AuthorizationText      string     `json:"authorizationText"`
+	RequiredCertifications [][]string `json:"requiredCertifications"` // Each entry lists alternatives
+}
+
+// KnockoutCriteria choose which hard requirements reject a resume before scoring
+type KnockoutCriteria struct {
+	Certifications    bool `json:"certifications"`    // Missing a certification the JD requires
+	WorkAuthorization bool `json:"workAuthorization"` // Needing sponsorship the JD rules out
+	Degree            bool `json:"degree"`            // Below the required degree with no equivalent experience accepted
+	MinimumYears      bool `json:"minimumYears"`      // Fewer years of experience than the JD's minimum
+	YearsGrace        int  `json:"yearsGrace"`        // Years short of the minimum still let through
+}
+
+// defaultKnockouts are used by the default profile and by any profile that leaves them out
+var defaultKnockouts = KnockoutCriteria{
+	Certifications:    true,
+	WorkAuthorization: true,
+	Degree:            true,
+	MinimumYears:      true,
+}
+
+// Knockout is a hard requirement the resume fails
+type Knockout struct {
+	Criterion string `json:"criterion"`
+	Reason    string `json:"reason"`
+}
+
+// evaluateKnockouts checks the resume against the JD's hard requirements the way an ATS
+// screens applications before ranking them. It returns the failed requirements and warnings
+// for requirements the resume doesn't show either way.
+func evaluateKnockouts(req ScoreRequest, education EducationMatch, criteria KnockoutCriteria) ([]Knockout, []string) {
+	knockouts := make([]Knockout, 0)
+	var warnings []string
+	requirements := req.JobRequirements
+
+	if criteria.Certifications {
+		for _, alternatives := range requirements.RequiredCertifications {
+			if len(alternatives) == 0 || !allMissing(req.MissingCertifications, alternatives) {
+				continue
+			}
+			reason := fmt.Sprintf("The job requires the %s certification, which your resume doesn't list.", alternatives[0])
+			if len(alternatives) > 1 {
+				reason = fmt.Sprintf("The job requires one of the %s certifications, and your resume lists none of them.", joinOr(alternatives))
+			}
+			knockouts = append(knockouts, Knockout{Criterion: KnockoutCertification, Reason: reason})
+		}
+	}
+
+	if criteria.WorkAuthorization && requirements.WorkAuthorization {
+		switch {
+		case req.WorkAuthorization == AuthorizationSponsoring && requirements.NoSponsorship:
+			knockouts = append(knockouts, Knockout{
+				Criterion: KnockoutWorkAuthorization,
+				Reason:    "The job does not sponsor visas and your resume states you need sponsorship.",
+			})
+		case req.WorkAuthorization == AuthorizationSponsoring:
+			knockouts = append(knockouts, Knockout{
+				Criterion: KnockoutWorkAuthorization,
+				Reason:    "The job requires authorization to work and your resume states you need sponsorship.",
+			})
+		case req.WorkAuthorization != AuthorizationStated:
+			warnings = append(warnings, "The job requires authorization to work. Many ATS systems ask a screening question about it and reject applicants who need sponsorship; consider stating your work authorization.")
+		}
+	}
+
+	if criteria.Degree && req.EducationRequirement != nil && req.EducationRequirement.Required &&
+		education.Status == EducationUnmet && !education.Equivalent {
+		reason := fmt.Sprintf("The job requires %s degree", withArticle(education.Required))
+		if education.Highest != "" {
+			reason += fmt.Sprintf(" and your highest degree is %s degree.", withArticle(education.Highest))
+		} else {
+			reason += " and your resume lists no degree."
+		}
+		knockouts = append(knockouts, Knockout{Criterion: KnockoutDegree, Reason: reason})
+	}
+
+	if criteria.MinimumYears && requirements.MinimumYears > 0 {
+		hasExperience := strings.TrimSpace(req.Sections["experience"]) != ""
+		switch {
+		case req.ExperienceYears == 0 && hasExperience:
+			// Positions without readable dates give no total to compare
+			warnings = append(warnings, fmt.Sprintf("The job requires %d+ years of experience, but no dates could be read from your experience section. Date each position so an ATS can count your years.", requirements.MinimumYears))
+		case req.ExperienceYears+criteria.YearsGrace < requirements.MinimumYears:
+			knockouts = append(knockouts, Knockout{
+				Criterion: KnockoutMinimumYears,
+				Reason: fmt.Sprintf("The job requires %d+ years of experience and your resume shows %d %s.",
+					requirements.MinimumYears, req.ExperienceYears, pluralNoun(req.ExperienceYears, "year")),
+			})
+		}
+	}
+
+	return knockouts, warnings
+}
+
+// withArticle puts "a" or "an" before a word
+func withArticle(word string) string {
+	if word != "" && strings.ContainsRune("aeiou", rune(word[0])) {
+		return "an " + word
+	}
+	return "a " + word
+}
+
+// allMissing reports whether every one of the alternatives is in the missing list
+func allMissing(missing []string, alternatives []string) bool {
+	for _, alternative := range alternatives {
+		if !containsFold(missing, alternative) {
+			return false
+		}
+	}
+	return true
+}
+
+// joinOr lists items as "A, B or C"
+func joinOr(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
+
+// containsFold reports whether list holds term, ignoring case
+func containsFold(list []string, term string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package scorer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateCertificationKnockouts(t *testing.T) {
+	criteria := KnockoutCriteria{Certifications: true}
+
+	tests := []struct {
+		name     string
+		required [][]string
+		missing  []string
+		want     []string
+	}{
+		{
+			name:     "holds one of the alternatives",
+			required: [][]string{{"CISSP", "CISM"}},
+			missing:  []string{"CISM"},
+			want:     []string{},
+		},
+		{
+			name:     "holds none of the alternatives",
+			required: [][]string{{"CISSP", "CISM"}},
+			missing:  []string{"CISSP", "CISM"},
+			want:     []string{"The job requires one of the CISSP or CISM certifications, and your resume lists none of them."},
+		},
+		{
+			name:     "missing a single required certification",
+			required: [][]string{{"CISSP"}, {"CISM"}},
+			missing:  []string{"CISM"},
+			want:     []string{"The job requires the CISM certification, which your resume doesn't list."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ScoreRequest{
+				MissingCertifications: tt.missing,
+				JobRequirements:       JobRequirements{RequiredCertifications: tt.required},
+			}
+			knockouts, _ := evaluateKnockouts(req, EducationMatch{}, criteria)
+			reasons := make([]string, 0, len(knockouts))
+			for _, knockout := range knockouts {
+				reasons = append(reasons, knockout.Reason)
+			}
+			if !reflect.DeepEqual(reasons, tt.want) {
+				t.Errorf("reasons = %q, want %q", reasons, tt.want)
+			}
+		})
+	}
+}
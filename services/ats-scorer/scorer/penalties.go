@@ -0,0 +1,81 @@
+package scorer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Gaming penalties, in overall score points
+const (
+	RepeatedTermPenalty = 5
+	MaxRepeatedPenalty  = 15
+	CopiedTextPenalty   = 15
+	HiddenTextPenalty   = 20
+	MaxGamingPenalty    = 35
+)
+
+// KeywordStuffing mirrors the stuffing report from the NLP service
+type KeywordStuffing struct {
+	RepeatedTerms   []string `json:"repeatedTerms"`
+	CopiedPhrases   []string `json:"copiedPhrases"`
+	CopiedWords     int      `json:"copiedWords"`
+	CopiedWordRatio float64  `json:"copiedWordRatio"`
+	Copied          bool     `json:"copied"`
+	Detected        bool     `json:"detected"`
+}
+
+// calculateGamingPenalty computes the score penalty and warnings for stuffing and hidden text
+func calculateGamingPenalty(stuffing KeywordStuffing, hiddenText []string) (int, []string) {
+	penalty := 0
+	warnings := make([]string, 0)
+
+	if len(stuffing.RepeatedTerms) > 0 {
+		repeatedPenalty := RepeatedTermPenalty * len(stuffing.RepeatedTerms)
+		if repeatedPenalty > MaxRepeatedPenalty {
+			repeatedPenalty = MaxRepeatedPenalty
+		}
+		penalty += repeatedPenalty
+		warnings = append(warnings, fmt.Sprintf(
+			"Keyword stuffing detected: %s repeated an abnormal number of times. ATS systems and recruiters penalize this.",
+			quoteList(stuffing.RepeatedTerms)))
+	}
+
+	if stuffing.Copied {
+		penalty += CopiedTextPenalty
+		warnings = append(warnings, fmt.Sprintf(
+			"%d words (%.0f%% of the resume) are copied verbatim from the job description. Describe your own experience instead.",
+			stuffing.CopiedWords, stuffing.CopiedWordRatio*100))
+	}
+
+	if len(hiddenText) > 0 {
+		penalty += HiddenTextPenalty
+		warnings = append(warnings, fmt.Sprintf(
+			"Hidden text detected (white or tiny font): %s. Many ATS systems flag or reject resumes with invisible text.",
+			quoteList(hiddenText)))
+	}
+
+	if penalty > MaxGamingPenalty {
+		penalty = MaxGamingPenalty
+	}
+
+	return penalty, warnings
+}
+
+// quoteList formats up to five items as a quoted, comma separated list
+func quoteList(items []string) string {
+	const maxItems = 5
+
+	quoted := make([]string, 0, maxItems)
+	for i, item := range items {
+		if i == maxItems {
+			quoted = append(quoted, fmt.Sprintf("and %d more", len(items)-maxItems))
+			break
+		}
+		if runes := []rune(item); len(runes) > 60 {
+			item = string(runes[:60]) + "..."
+		}
+		quoted = append(quoted, fmt.Sprintf("%q", item))
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,125 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+)
+
+// Readability mirrors the resume length and readability statistics from the NLP service
+type Readability struct {
+	WordCount          int     `json:"wordCount"`
+	SentenceCount      int     `json:"sentenceCount"`
+	BulletCount        int     `json:"bulletCount"`
+	LineCount          int     `json:"lineCount"`
+	AvgSentenceLength  float64 `json:"avgSentenceLength"`
+	AvgBulletLength    float64 `json:"avgBulletLength"`
+	FleschReadingEase  float64 `json:"fleschReadingEase"`
+	FleschKincaidGrade float64 `json:"fleschKincaidGrade"`
+	EstimatedPages     float64 `json:"estimatedPages"`
+	BulletDensity      float64 `json:"bulletDensity"`
+}
+
+// Presentation thresholds
+const (
+	minResumeWords       = 250  // Below this a resume reads as thin
+	maxAvgBulletWords    = 30   // Longer bullets are skimmed past
+	minAvgBulletWords    = 6    // Shorter bullets rarely say what was achieved
+	maxAvgSentenceWords  = 25   // Longer sentences are hard to scan
+	maxReadingGrade      = 16.0 // Flesch-Kincaid grade beyond a college graduate
+	minBulletDensity     = 0.2  // Share of lines that should be bullets
+	pageLengthTolerance  = 0.2  // Pages over the target before length is flagged
+	maxLengthPenalty     = 25
+	minPresentationScore = 30
+)
+
+// targetPages is the usual resume length for a candidate's years of experience
+func targetPages(experienceYears int) int {
+	switch {
+	case experienceYears < 5:
+		return 1
+	case experienceYears < 15:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// evaluatePresentation scores resume length and readability against the length that is
+// usual for the candidate's experience
+func evaluatePresentation(readability Readability, experienceYears int) (int, string, []string) {
+	score := 85
+	details := make([]string, 0)
+
+	pages := targetPages(experienceYears)
+	if over := readability.EstimatedPages - float64(pages); over > pageLengthTolerance {
+		score -= int(math.Min(maxLengthPenalty, math.Round(over*20)))
+		details = append(details, fmt.Sprintf(
+			"The resume runs about %.1f pages; %d %s is usual with %s. Cut older or less relevant detail.",
+			readability.EstimatedPages, pages, pluralNoun(pages, "page"), experienceLabel(experienceYears)))
+	}
+
+	if readability.WordCount < minResumeWords {
+		score -= 15
+		details = append(details, fmt.Sprintf(
+			"The resume has only %d words. Describe your work and results in more detail.", readability.WordCount))
+	}
+
+	if readability.BulletCount > 0 && readability.AvgBulletLength > maxAvgBulletWords {
+		score -= 10
+		details = append(details, fmt.Sprintf(
+			"Bullets average %.0f words. Keep each to one or two lines so reviewers can skim them.", readability.AvgBulletLength))
+	} else if readability.BulletCount > 0 && readability.AvgBulletLength < minAvgBulletWords {
+		score -= 5
+		details = append(details, fmt.Sprintf(
+			"Bullets average %.0f words. Say what you did and what it achieved.", readability.AvgBulletLength))
+	}
+
+	if readability.AvgSentenceLength > maxAvgSentenceWords {
+		score -= 5
+		details = append(details, fmt.Sprintf(
+			"Sentences average %.0f words. Split long sentences.", readability.AvgSentenceLength))
+	}
+
+	if readability.FleschKincaidGrade > maxReadingGrade {
+		score -= 10
+		details = append(details, fmt.Sprintf(
+			"The text reads at grade %.0f (Flesch-Kincaid). Prefer shorter words and sentences.", readability.FleschKincaidGrade))
+	}
+
+	if readability.WordCount >= minResumeWords && readability.BulletDensity < minBulletDensity {
+		score -= 10
+		details = append(details, "Few lines are bullets. Break paragraphs into bullets so each achievement stands out.")
+	}
+
+	if score < minPresentationScore {
+		score = minPresentationScore
+	}
+
+	var feedback string
+	switch len(details) {
+	case 0:
+		feedback = fmt.Sprintf("Well-sized and easy to scan for a resume with %s.", experienceLabel(experienceYears))
+	case 1:
+		feedback = details[0]
+	default:
+		feedback = fmt.Sprintf("%s See details for %d more %s.", details[0], len(details)-1, pluralNoun(len(details)-1, "suggestion"))
+	}
+
+	return score, feedback, details
+}
+
+// pluralNoun returns noun in the plural unless count is 1
+func pluralNoun(count int, noun string) string {
+	if count == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+// experienceLabel describes years of experience for feedback
+func experienceLabel(years int) string {
+	if years == 0 {
+		return "no dated experience"
+	}
+	return fmt.Sprintf("%d %s of experience", years, pluralNoun(years, "year"))
+}
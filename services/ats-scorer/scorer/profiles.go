@@ -0,0 +1,163 @@
+package scorer
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is used when a request names no profile; its weights come from the environment
+const DefaultProfile = "default"
+
+// ComponentWeights weight the parts of the overall score
+type ComponentWeights struct {
+	Skill      float64 `json:"skill"`
+	Similarity float64 `json:"similarity"`
+	Section    float64 `json:"section"`
+	Title      float64 `json:"title"`
+}
+
+// SectionThresholds are the amounts a section needs for full marks; smaller amounts
+// earn partial marks
+type SectionThresholds struct {
+	SkillsWords       int     `json:"skillsWords"`
+	ExperienceWords   int     `json:"experienceWords"`
+	ProjectsWords     int     `json:"projectsWords"`
+	EducationWords    int     `json:"educationWords"`    // Used when no degree is recognized
+	StrongOpenerRatio float64 `json:"strongOpenerRatio"` // Share of bullets opening with an action verb
+	MetricRatio       float64 `json:"metricRatio"`       // Share of bullets with a measurable result
+}
+
+// ScoringProfile tunes scoring for a role family
+type ScoringProfile struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Weights     ComponentWeights `json:"weights"`
+	// SectionWeights weight each section in the section average; sections left out weigh 1
+	SectionWeights map[string]float64 `json:"sectionWeights"`
+	Thresholds     SectionThresholds  `json:"thresholds"`
+	Knockouts      KnockoutCriteria   `json:"knockouts"`
+}
+
+// defaultThresholds are the thresholds used by the default profile and by any profile
+// that leaves a threshold out
+var defaultThresholds = SectionThresholds{
+	SkillsWords:       30,
+	ExperienceWords:   200,
+	ProjectsWords:     100,
+	EducationWords:    50,
+	StrongOpenerRatio: 0.6,
+	MetricRatio:       0.5,
+}
+
+// Built-in scoring profiles; SCORING_PROFILE_DIR can add profiles or override these
+//
+//go:embed profiles/*.json
+var embeddedProfiles embed.FS
+
+var profiles = loadProfiles()
+
+// loadProfiles reads the embedded profile files, then any files in SCORING_PROFILE_DIR.
+// Component weights are normalized to sum to 1; a profile that can't be used is recorded
+// as a configuration error.
+func loadProfiles() map[string]ScoringProfile {
+	loaded := map[string]ScoringProfile{
+		DefaultProfile: {
+			Name:        DefaultProfile,
+			Description: "General scoring with weights from SKILL_WEIGHT, SIMILARITY_WEIGHT, SECTION_WEIGHT and TITLE_WEIGHT",
+			Weights: ComponentWeights{
+				Skill:      SkillWeight,
+				Similarity: SimilarityWeight,
+				Section:    SectionWeight,
+				Title:      TitleWeight,
+			},
+			SectionWeights: map[string]float64{},
+			Thresholds:     defaultThresholds,
+			Knockouts:      defaultKnockouts,
+		},
+	}
+	checkProfile(loaded, "environment", loaded[DefaultProfile])
+
+	files, _ := fs.Glob(embeddedProfiles, "profiles/*.json")
+	for _, file := range files {
+		data, err := embeddedProfiles.ReadFile(file)
+		if err != nil {
+			configErrors = append(configErrors, fmt.Sprintf("profile file %s: %v", file, err))
+			continue
+		}
+		addProfileFile(loaded, file, data)
+	}
+
+	if dir := os.Getenv("SCORING_PROFILE_DIR"); dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				configErrors = append(configErrors, fmt.Sprintf("profile file %s: %v", file, err))
+				continue
+			}
+			addProfileFile(loaded, file, data)
+		}
+	}
+
+	return loaded
+}
+
+// addProfileFile decodes a profile file and stores it by name, replacing any earlier definition.
+// Weights, thresholds and knockout criteria the file leaves out keep the default profile's values.
+func addProfileFile(loaded map[string]ScoringProfile, file string, data []byte) {
+	profile := ScoringProfile{Weights: loaded[DefaultProfile].Weights, Thresholds: defaultThresholds, Knockouts: defaultKnockouts}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		configErrors = append(configErrors, fmt.Sprintf("profile file %s: %v", file, err))
+		return
+	}
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	profile.Name = strings.ToLower(profile.Name)
+	if profile.SectionWeights == nil {
+		profile.SectionWeights = map[string]float64{}
+	}
+	checkProfile(loaded, file, profile)
+}
+
+// checkProfile validates a profile and stores it by name, recording any problems as
+// configuration errors
+func checkProfile(loaded map[string]ScoringProfile, source string, profile ScoringProfile) {
+	for _, err := range validateProfile(&profile) {
+		configErrors = append(configErrors, fmt.Sprintf("profile %q (%s): %s", profile.Name, source, err))
+	}
+	loaded[profile.Name] = profile
+}
+
+// LookupProfile returns the named profile, or the default profile for an empty name
+func LookupProfile(name string) (ScoringProfile, bool) {
+	if name == "" {
+		name = DefaultProfile
+	}
+	profile, ok := profiles[strings.ToLower(name)]
+	return profile, ok
+}
+
+// ProfileNames lists the available profiles in alphabetical order
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sectionWeight is how much a section counts in the profile's section average
+func (p ScoringProfile) sectionWeight(section string) float64 {
+	if weight, ok := p.SectionWeights[section]; ok {
+		return weight
+	}
+	return 1
+}
@@ -0,0 +1,70 @@
+package scorer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSectionAverageByProfile(t *testing.T) {
+	sections := map[string]SectionScore{"experience": {Score: 90}, "projects": {Score: 50}}
+
+	tests := []struct {
+		profile string
+		want    float64
+	}{
+		{DefaultProfile, 70},
+		{"software_engineer", 74},  // (90×1.5 + 50×1.0) / 2.5
+		{"new_grad", 63.91},        // (90×0.8 + 50×1.5) / 2.3
+		{"designer", 67.78},        // (90×1.2 + 50×1.5) / 2.7
+		{"product_manager", 80.48}, // (90×1.6 + 50×0.5) / 2.1
+		{"data_scientist", 70.8},   // (90×1.3 + 50×1.2) / 2.5
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			profile, ok := LookupProfile(tt.profile)
+			if !ok {
+				t.Fatalf("profile %q not found", tt.profile)
+			}
+			_, explanation := calculateOverallScore(60, 60, sections, 60, true, profile)
+			if got := explanation.Components[2].RawScore; got != tt.want {
+				t.Errorf("section average = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadProfilesRecordsErrors(t *testing.T) {
+	saved := configErrors
+	defer func() { configErrors = saved }()
+
+	tests := []struct {
+		name  string
+		setup func(dir string) error
+		want  string
+	}{
+		// A directory with a .json name can't be read as a file
+		{"unreadable file", func(dir string) error { return os.Mkdir(filepath.Join(dir, "broken.json"), 0o755) }, "broken.json"},
+		{"invalid JSON", func(dir string) error {
+			return os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"name": `), 0o644)
+		}, "broken.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := tt.setup(dir); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv("SCORING_PROFILE_DIR", dir)
+
+			configErrors = nil
+			loadProfiles()
+			if len(configErrors) == 0 || !strings.Contains(strings.Join(configErrors, "\n"), tt.want) {
+				t.Errorf("config errors = %q, want one mentioning %q", configErrors, tt.want)
+			}
+		})
+	}
+}
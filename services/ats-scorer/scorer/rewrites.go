@@ -0,0 +1,231 @@
+package scorer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maxRewrites limits how many bullets get a suggested rewrite
+const maxRewrites = 5
+
+// BulletRewrite is a suggested rewrite of a weak experience bullet. Text in square
+// brackets is a placeholder for the candidate to fill in or drop.
+type BulletRewrite struct {
+	Original  string   `json:"original"`
+	Suggested string   `json:"suggested"`
+	Reasons   []string `json:"reasons"`
+}
+
+// taskVerbs picks an opening verb from words in the task, checked in order
+var taskVerbs = []struct {
+	verb  string
+	words []string
+}{
+	{"Led", []string{"engineers", "interns", "developers", "hiring", "mentoring", "onboarding"}},
+	{"Resolved", []string{"bug", "bugs", "issue", "issues", "incident", "incidents", "outage", "tickets", "support"}},
+	{"Improved", []string{"performance", "latency", "cost", "costs", "process", "reliability", "speed", "quality"}},
+	{"Analyzed", []string{"data", "analysis", "report", "reports", "metrics", "research", "dashboard", "dashboards"}},
+	{"Automated", []string{"deployment", "deployments", "testing", "tests", "ci", "pipeline", "pipelines", "scripts"}},
+	{"Built", []string{"app", "application", "service", "services", "feature", "features", "api", "system", "tool", "tools", "website"}},
+}
+
+// weakOpenerVerbs replace a weak opening when nothing in the task suggests a better verb
+var weakOpenerVerbs = map[string]string{
+	"responsible for":     "Managed",
+	"was responsible for": "Managed",
+	"in charge of":        "Managed",
+	"tasked with":         "Managed",
+	"duties included":     "Managed",
+	"handled":             "Managed",
+	"worked on":           "Contributed to",
+	"worked with":         "Partnered with",
+	"involved in":         "Contributed to",
+	"was involved in":     "Contributed to",
+	"participated in":     "Contributed to",
+	"was part of":         "Contributed to",
+	"part of":             "Contributed to",
+}
+
+// assistingOpeners describe helping someone else, so the rewrite keeps that instead of
+// claiming ownership with a verb picked from the task
+var assistingOpeners = map[string]bool{
+	"helped with": true, "helped to": true, "helped": true, "assisted with": true, "assisted in": true,
+}
+
+// assistingVerb replaces an assisting opener
+const assistingVerb = "Supported"
+
+// defaultRewriteVerb opens a rewrite when neither the task nor the weak opening suggests a verb
+const defaultRewriteVerb = "Delivered"
+
+// verbPlaceholders suggest the kind of result to add after a specific verb
+var verbPlaceholders = map[string]string{
+	"resolved": "cutting [issues or downtime] by [X]%",
+	"reduced":  "a [X]% reduction",
+	"led":      "with a team of [N], delivering [outcome]",
+}
+
+// metricPlaceholders suggest the kind of result to add, by action verb category
+var metricPlaceholders = map[string]string{
+	"leadership":    "for [N] people, delivering [outcome]",
+	"building":      "used by [N] users or saving [N] hours a week",
+	"improvement":   "improving [metric] by [X]%",
+	"analysis":      "informing [decision] worth [$ or %]",
+	"communication": "reaching [N] stakeholders",
+	"achievement":   "worth [$ or %]",
+}
+
+var (
+	// passiveAgentPattern matches the "by our team" that follows a passive verb
+	passiveAgentPattern = regexp.MustCompile(`(?i)^\s*by\s+(?:the|our|my|a)?\s*\w+\b`)
+	// possessivePronounPattern matches "my" and "our", which become "the" in a rewrite
+	possessivePronounPattern = regexp.MustCompile(`\b(?:[Mm]y|[Oo]ur)\b`)
+	// leadingPronounPattern matches a pronoun opening a bullet, which is dropped from a rewrite
+	leadingPronounPattern = regexp.MustCompile(`^(?:I|[Ww]e)\s+`)
+)
+
+// suggestRewrites proposes template rewrites for weak experience bullets: a strong verb,
+// the task, a JD keyword the resume is missing and a placeholder for the result.
+// The suggestions are deterministic so the same resume always gets the same advice.
+func suggestRewrites(content string, missingKeywords []string) []BulletRewrite {
+	rewrites := make([]BulletRewrite, 0)
+	keywordIndex := 0
+
+	for _, bullet := range experienceBullets(content) {
+		if len(rewrites) == maxRewrites {
+			break
+		}
+
+		// "I built" and "We migrated" are judged by the verb after the pronoun
+		text := leadingPronounPattern.ReplaceAllString(bullet, "")
+		verb := openerVerb(text)
+		weak := weakOpener(text)
+		passive := passivePattern.FindStringSubmatchIndex(text)
+		hasPronoun := pronounPattern.MatchString(bullet)
+		quantified := hasQuantifiedImpact(bullet)
+
+		var reasons []string
+		switch {
+		case weak != "":
+			reasons = append(reasons, "weak opening")
+		case verb == "":
+			reasons = append(reasons, "no action verb")
+		}
+		if passive != nil {
+			reasons = append(reasons, "passive voice")
+		}
+		if hasPronoun {
+			reasons = append(reasons, "first-person pronoun")
+		}
+		if !quantified {
+			reasons = append(reasons, "no measurable result")
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+
+		// Work out the task and the verb that should open it
+		task := strings.TrimRight(strings.TrimSpace(text), ".;")
+		switch {
+		case passive != nil && verb == "" && weak == "":
+			// "The API was redesigned by our team" becomes "Redesigned the API"
+			participle := strings.ToLower(text[passive[2]:passive[3]])
+			rest := passiveAgentPattern.ReplaceAllString(text[passive[1]:], "")
+			task = strings.TrimRight(strings.TrimSpace(text[:passive[0]]+" "+rest), ".;")
+			if _, ok := actionVerbCategories[participle]; ok {
+				verb = participle
+			}
+		case weak != "":
+			task = strings.TrimSpace(task[len(weak):])
+		case verb != "":
+			task = strings.TrimSpace(task[len(strings.Fields(task)[0]):])
+			if verb == "set up" {
+				task = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(task, "up"), "Up"))
+			}
+		}
+		task = possessivePronounPattern.ReplaceAllString(task, "the")
+		task = lowerFirst(strings.TrimSpace(task))
+		if task == "" {
+			continue
+		}
+
+		opening := chooseRewriteVerb(verb, weak, task)
+		suggested := opening + " " + task
+
+		// Suggest a missing JD keyword the candidate may have used here
+		for keywordIndex < len(missingKeywords) && containsTerm(bullet, missingKeywords[keywordIndex]) {
+			keywordIndex++
+		}
+		if keywordIndex < len(missingKeywords) {
+			suggested += " using [" + missingKeywords[keywordIndex] + ", if you used it]"
+			keywordIndex++
+		}
+
+		if !quantified {
+			suggested += ", " + resultPlaceholder(opening)
+		}
+
+		rewrites = append(rewrites, BulletRewrite{Original: bullet, Suggested: suggested + ".", Reasons: reasons})
+	}
+
+	return rewrites
+}
+
+// chooseRewriteVerb keeps a strong opening verb or picks one from the words of the task,
+// then from the weak opening it replaces
+func chooseRewriteVerb(verb, weak, task string) string {
+	if verb != "" {
+		return strings.ToUpper(verb[:1]) + verb[1:]
+	}
+	if assistingOpeners[weak] {
+		return assistingVerb
+	}
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(task), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	for _, candidate := range taskVerbs {
+		for _, word := range candidate.words {
+			if words[word] {
+				return candidate.verb
+			}
+		}
+	}
+	if replacement, ok := weakOpenerVerbs[weak]; ok {
+		return replacement
+	}
+	return defaultRewriteVerb
+}
+
+// lowerFirst lower-cases the first letter of text unless it starts an acronym or a name
+// such as "API" or "PostgreSQL"
+func lowerFirst(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return text
+	}
+	first := []rune(fields[0])
+	for _, r := range first[1:] {
+		if unicode.IsUpper(r) {
+			return text
+		}
+	}
+	runes := []rune(text)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// resultPlaceholder suggests the kind of measurable result to add after an opening verb
+func resultPlaceholder(opening string) string {
+	verb := strings.ToLower(opening)
+	if placeholder, ok := verbPlaceholders[verb]; ok {
+		return placeholder
+	}
+	if placeholder, ok := metricPlaceholders[actionVerbCategories[verb]]; ok {
+		return placeholder
+	}
+	return "resulting in [measurable outcome]"
+}
@@ -0,0 +1,388 @@
+package scorer
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OverallTarget is the rule target that adjusts the overall score instead of a section
+const OverallTarget = "overall"
+
+// Condition is a test over extracted features. A leaf compares one feature with a value;
+// "all", "any" and "not" combine other conditions.
+type Condition struct {
+	Feature string      `json:"feature,omitempty"`
+	Op      string      `json:"op,omitempty"` // >, >=, <, <=, ==, !=, in, contains
+	Value   interface{} `json:"value"`
+	All     []Condition `json:"all,omitempty"`
+	Any     []Condition `json:"any,omitempty"`
+	Not     *Condition  `json:"not,omitempty"`
+}
+
+// MarshalJSON writes a leaf's value even when it is false or 0, and leaves the value out of
+// "all", "any" and "not" conditions, which have none
+func (c Condition) MarshalJSON() ([]byte, error) {
+	type leaf Condition
+	if c.Feature != "" {
+		return json.Marshal(leaf(c))
+	}
+	return json.Marshal(struct {
+		All []Condition `json:"all,omitempty"`
+		Any []Condition `json:"any,omitempty"`
+		Not *Condition  `json:"not,omitempty"`
+	}{c.All, c.Any, c.Not})
+}
+
+// Rule adjusts a section or the overall score when its condition holds
+type Rule struct {
+	ID       string    `json:"id"`
+	Target   string    `json:"target"` // A section name or "overall"
+	When     Condition `json:"when"`
+	Delta    int       `json:"delta"`
+	Feedback string    `json:"feedback"`
+}
+
+// RuleTest checks which rules fire for a set of features
+type RuleTest struct {
+	Name     string   `json:"name"`
+	Features Features `json:"features"`
+	Fires    []string `json:"fires"` // Exactly the rule IDs expected to fire
+}
+
+// RuleSet is one rules file: its rules and the tests that must pass before it is used
+type RuleSet struct {
+	Name  string     `json:"name"`
+	Rules []Rule     `json:"rules"`
+	Tests []RuleTest `json:"tests"`
+}
+
+// AppliedRule records a rule that fired for a request
+type AppliedRule struct {
+	Rule     string `json:"rule"`
+	Target   string `json:"target"`
+	Delta    int    `json:"delta"`
+	Feedback string `json:"feedback"`
+}
+
+// Built-in rule sets; SCORING_RULES_DIR can add rule sets or override these
+//
+//go:embed rulesets/*.json
+var embeddedRuleSets embed.FS
+
+var ruleSets = loadRuleSets()
+
+// loadRuleSets reads the embedded rule files, then any files in SCORING_RULES_DIR.
+// A file that can't be read, has invalid rules or fails its tests is a configuration error.
+func loadRuleSets() []RuleSet {
+	loaded := make(map[string]RuleSet)
+
+	files, _ := fs.Glob(embeddedRuleSets, "rulesets/*.json")
+	for _, file := range files {
+		data, err := embeddedRuleSets.ReadFile(file)
+		if err != nil {
+			configErrors = append(configErrors, fmt.Sprintf("rules file %s: %v", file, err))
+			continue
+		}
+		addRuleFile(loaded, file, data)
+	}
+
+	if dir := os.Getenv("SCORING_RULES_DIR"); dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				configErrors = append(configErrors, fmt.Sprintf("rules file %s: %v", file, err))
+				continue
+			}
+			addRuleFile(loaded, file, data)
+		}
+	}
+
+	names := make([]string, 0, len(loaded))
+	for name := range loaded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sets := make([]RuleSet, 0, len(names))
+	for _, name := range names {
+		sets = append(sets, loaded[name])
+	}
+	return sets
+}
+
+// addRuleFile decodes, validates and tests a rules file, then stores it by name,
+// replacing any earlier rule set with the same name. A file that fails is a configuration
+// error, so the service doesn't start with rules missing.
+func addRuleFile(loaded map[string]RuleSet, file string, data []byte) {
+	var set RuleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		configErrors = append(configErrors, fmt.Sprintf("rules file %s: invalid JSON: %v", file, err))
+		return
+	}
+	if set.Name == "" {
+		set.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	if errs := ValidateRuleSet(set); len(errs) > 0 {
+		for _, err := range errs {
+			configErrors = append(configErrors, fmt.Sprintf("rules file %s: %s", file, err))
+		}
+		return
+	}
+	loaded[set.Name] = set
+}
+
+// CheckRuleFile validates a rules file and runs its tests without loading it
+func CheckRuleFile(file string) []string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var set RuleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return []string{fmt.Sprintf("invalid JSON: %v", err)}
+	}
+	return ValidateRuleSet(set)
+}
+
+// ValidateRuleSet checks a rule set's rules and runs its tests, returning one message per problem
+func ValidateRuleSet(set RuleSet) []string {
+	var errs []string
+	known := featureNames()
+	targets := map[string]bool{OverallTarget: true}
+	for _, section := range scoredSections {
+		targets[section] = true
+	}
+
+	ids := make(map[string]bool)
+	for i, rule := range set.Rules {
+		if rule.ID == "" {
+			errs = append(errs, fmt.Sprintf("rule %d has no id", i+1))
+			continue
+		}
+		if ids[rule.ID] {
+			errs = append(errs, fmt.Sprintf("rule %q: duplicate id", rule.ID))
+		}
+		ids[rule.ID] = true
+		if !targets[rule.Target] {
+			errs = append(errs, fmt.Sprintf("rule %q: unknown target %q", rule.ID, rule.Target))
+		}
+		for _, err := range validateCondition(rule.When, known) {
+			errs = append(errs, fmt.Sprintf("rule %q: %s", rule.ID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for _, test := range set.Tests {
+		features := Features{}
+		for name, value := range test.Features {
+			if !known[name] {
+				errs = append(errs, fmt.Sprintf("test %q: unknown feature %q", test.Name, name))
+			}
+			features[name] = normalizeFeature(value)
+		}
+
+		var fired []string
+		for _, rule := range set.Rules {
+			if rule.When.matches(features) {
+				fired = append(fired, rule.ID)
+			}
+		}
+		expected := append([]string(nil), test.Fires...)
+		sort.Strings(fired)
+		sort.Strings(expected)
+		if strings.Join(fired, ",") != strings.Join(expected, ",") {
+			errs = append(errs, fmt.Sprintf("test %q: expected rules [%s] to fire, got [%s]",
+				test.Name, strings.Join(expected, ", "), strings.Join(fired, ", ")))
+		}
+	}
+	return errs
+}
+
+// validateCondition checks that a condition uses known features and operators
+func validateCondition(cond Condition, known map[string]bool) []string {
+	var errs []string
+	combined := len(cond.All) + len(cond.Any)
+	if cond.Not != nil {
+		combined++
+	}
+
+	switch {
+	case cond.Feature == "" && combined == 0:
+		errs = append(errs, "empty condition")
+	case cond.Feature != "" && combined > 0:
+		errs = append(errs, fmt.Sprintf("condition on %q cannot also combine other conditions", cond.Feature))
+	case cond.Feature != "":
+		if !known[cond.Feature] {
+			errs = append(errs, fmt.Sprintf("unknown feature %q", cond.Feature))
+		}
+		switch cond.Op {
+		case ">", ">=", "<", "<=":
+			if _, ok := cond.Value.(float64); !ok {
+				errs = append(errs, fmt.Sprintf("%q on %q needs a number", cond.Op, cond.Feature))
+			}
+		case "==", "!=", "contains":
+		case "in":
+			if _, ok := cond.Value.([]interface{}); !ok {
+				errs = append(errs, fmt.Sprintf("\"in\" on %q needs a list", cond.Feature))
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("unknown operator %q", cond.Op))
+		}
+	}
+
+	for _, sub := range append(append([]Condition(nil), cond.All...), cond.Any...) {
+		errs = append(errs, validateCondition(sub, known)...)
+	}
+	if cond.Not != nil {
+		errs = append(errs, validateCondition(*cond.Not, known)...)
+	}
+	return errs
+}
+
+// matches reports whether the condition holds for the features. A missing feature never matches.
+func (cond Condition) matches(features Features) bool {
+	if cond.Feature == "" {
+		for _, sub := range cond.All {
+			if !sub.matches(features) {
+				return false
+			}
+		}
+		if len(cond.Any) > 0 {
+			matched := false
+			for _, sub := range cond.Any {
+				if sub.matches(features) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		if cond.Not != nil && cond.Not.matches(features) {
+			return false
+		}
+		return true
+	}
+
+	value, ok := features[cond.Feature]
+	if !ok {
+		return false
+	}
+
+	switch cond.Op {
+	case ">", ">=", "<", "<=":
+		number, isNumber := value.(float64)
+		limit, limitIsNumber := cond.Value.(float64)
+		if !isNumber || !limitIsNumber {
+			return false
+		}
+		switch cond.Op {
+		case ">":
+			return number > limit
+		case ">=":
+			return number >= limit
+		case "<":
+			return number < limit
+		default:
+			return number <= limit
+		}
+	case "==":
+		return featureEquals(value, cond.Value)
+	case "!=":
+		return !featureEquals(value, cond.Value)
+	case "in":
+		options, _ := cond.Value.([]interface{})
+		for _, option := range options {
+			if featureEquals(value, option) {
+				return true
+			}
+		}
+		return false
+	case "contains":
+		list, _ := value.([]string)
+		for _, item := range list {
+			if featureEquals(item, cond.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// featureEquals compares a feature with a rule value; strings compare without case
+func featureEquals(feature, value interface{}) bool {
+	if a, ok := feature.(string); ok {
+		b, ok := value.(string)
+		return ok && strings.EqualFold(a, b)
+	}
+	return feature == value
+}
+
+// normalizeFeature converts decoded JSON test values to the types extractFeatures produces
+func normalizeFeature(value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			items = append(items, strings.ToLower(fmt.Sprint(item)))
+		}
+		return items
+	}
+	return value
+}
+
+// applyRules runs every loaded rule against the features, adjusting the section scores in
+// place. It returns the rules that fired and the total adjustment to the overall score.
+func applyRules(features Features, sectionScores map[string]SectionScore) ([]AppliedRule, int) {
+	applied := make([]AppliedRule, 0)
+	overallDelta := 0
+
+	for _, set := range ruleSets {
+		for _, rule := range set.Rules {
+			if !rule.When.matches(features) {
+				continue
+			}
+
+			if rule.Target == OverallTarget {
+				overallDelta += rule.Delta
+			} else {
+				// Rules only adjust sections that were scored
+				section, ok := sectionScores[rule.Target]
+				if !ok {
+					continue
+				}
+				section.Score = clampScore(section.Score + rule.Delta)
+				if rule.Feedback != "" {
+					section.Details = append(section.Details, rule.Feedback)
+				}
+				sectionScores[rule.Target] = section
+			}
+
+			applied = append(applied, AppliedRule{Rule: rule.ID, Target: rule.Target, Delta: rule.Delta, Feedback: rule.Feedback})
+		}
+	}
+
+	return applied, overallDelta
+}
+
+// clampScore keeps a score between 0 and 100
+func clampScore(score int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
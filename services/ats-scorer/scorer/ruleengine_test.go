@@ -0,0 +1,85 @@
+package scorer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestConditionJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		cond Condition
+		want string
+	}{
+		{
+			name: "false value",
+			cond: Condition{Feature: "sections.projects.present", Op: "==", Value: false},
+			want: `{"feature":"sections.projects.present","op":"==","value":false}`,
+		},
+		{
+			name: "zero value",
+			cond: Condition{Feature: "skills.missing", Op: "==", Value: 0.0},
+			want: `{"feature":"skills.missing","op":"==","value":0}`,
+		},
+		{
+			name: "composite",
+			cond: Condition{All: []Condition{{Feature: "profile", Op: "==", Value: "new_grad"}}},
+			want: `{"all":[{"feature":"profile","op":"==","value":"new_grad"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.cond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("json = %s, want %s", data, tt.want)
+			}
+
+			var decoded Condition
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if again, _ := json.Marshal(decoded); string(again) != tt.want {
+				t.Errorf("round trip = %s, want %s", again, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddRuleFileRecordsErrors(t *testing.T) {
+	saved := configErrors
+	defer func() { configErrors = saved }()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"invalid JSON", `{"name": "broken"`, "invalid JSON"},
+		{"unknown feature", `{"name": "custom", "rules": [{"id": "r1", "target": "overall", "when": {"feature": "nope", "op": ">", "value": 1}, "delta": 1}]}`, "nope"},
+		{
+			name: "failing test",
+			data: `{"name": "custom", "rules": [{"id": "r1", "target": "overall", "when": {"feature": "skills.missing", "op": ">", "value": 3}, "delta": -2}],
+				"tests": [{"name": "many missing", "features": {"skills.missing": 5}, "fires": []}]}`,
+			want: "many missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configErrors = nil
+			loaded := make(map[string]RuleSet)
+			addRuleFile(loaded, "custom.json", []byte(tt.data))
+			if len(loaded) != 0 {
+				t.Errorf("invalid rule set was loaded")
+			}
+			if len(configErrors) == 0 || !strings.Contains(strings.Join(configErrors, "\n"), tt.want) {
+				t.Errorf("config errors = %q, want one mentioning %q", configErrors, tt.want)
+			}
+		})
+	}
+}
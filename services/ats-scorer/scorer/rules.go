@@ -1,22 +1,29 @@
 package scorer
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 )
 
 // Scoring weights (configurable via environment)
 var (
-	SkillWeight      = getEnvFloat("SKILL_WEIGHT", 0.40)
-	SimilarityWeight = getEnvFloat("SIMILARITY_WEIGHT", 0.30)
+	SkillWeight      = getEnvFloat("SKILL_WEIGHT", 0.35)
+	SimilarityWeight = getEnvFloat("SIMILARITY_WEIGHT", 0.25)
 	SectionWeight    = getEnvFloat("SECTION_WEIGHT", 0.30)
+	TitleWeight      = getEnvFloat("TITLE_WEIGHT", 0.10)
 )
 
+// getEnvFloat reads a number from the environment; a value that isn't a number is a
+// configuration error
 func getEnvFloat(key string, fallback float64) float64 {
 	if value := os.Getenv(key); value != "" {
-		if f, err := strconv.ParseFloat(value, 64); err == nil {
-			return f
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			configErrors = append(configErrors, fmt.Sprintf("%s=%q is not a number", key, value))
+			return fallback
 		}
+		return f
 	}
 	return fallback
 }
@@ -31,3 +38,70 @@ func calculateSkillScore(matched, missing []string) float64 {
 	matchRatio := float64(len(matched)) / float64(totalSkills)
 	return matchRatio * 100
 }
+
+// Recency weights applied to matched skills when the JD asks for current hands-on use; otherwise
+// every matched skill counts in full
+const (
+	CurrentSkillWeight  = 1.0  // Used in the current role or within the last year
+	RecentSkillWeight   = 0.85 // Used within the last 3 years
+	AgingSkillWeight    = 0.6  // Used within the last 6 years
+	StaleSkillWeight    = 0.4  // Last used more than 6 years ago
+	UndatedSkillWeight  = 0.75 // Listed but not tied to a dated position
+	StaleSkillThreshold = 0.6  // Weights at or below this are reported as stale
+)
+
+// SkillProfile mirrors the per-skill recency estimate from the NLP service
+type SkillProfile struct {
+	Skill    string `json:"skill"`
+	LastUsed int    `json:"lastUsed"`
+	Current  bool   `json:"current"`
+}
+
+// recencyWeight scores how recently a skill was used before referenceYear, from 0 to 1
+func recencyWeight(profile SkillProfile, found bool, referenceYear int) float64 {
+	if !found || profile.LastUsed == 0 {
+		return UndatedSkillWeight
+	}
+
+	age := referenceYear - profile.LastUsed
+	switch {
+	case profile.Current || age <= 1:
+		return CurrentSkillWeight
+	case age <= 3:
+		return RecentSkillWeight
+	case age <= 6:
+		return AgingSkillWeight
+	}
+	return StaleSkillWeight
+}
+
+// calculateRecencyWeightedSkillScore weights each matched skill by how recently it was used
+// before referenceYear. Recency only matters when the JD asks for current use and the NLP
+// service dated the skills; otherwise this is the plain skill score.
+func calculateRecencyWeightedSkillScore(matched, missing []string, profiles []SkillProfile, requiresCurrentUse bool, referenceYear int) (float64, []string) {
+	totalSkills := len(matched) + len(missing)
+	if totalSkills == 0 {
+		return 50, nil
+	}
+	if !requiresCurrentUse || len(profiles) == 0 {
+		return calculateSkillScore(matched, missing), nil
+	}
+
+	bySkill := make(map[string]SkillProfile)
+	for _, profile := range profiles {
+		bySkill[profile.Skill] = profile
+	}
+
+	var weighted float64
+	stale := make([]string, 0)
+	for _, skill := range matched {
+		profile, found := bySkill[skill]
+		weight := recencyWeight(profile, found, referenceYear)
+		weighted += weight
+		if found && profile.LastUsed > 0 && weight <= StaleSkillThreshold {
+			stale = append(stale, skill)
+		}
+	}
+
+	return weighted / float64(totalSkills) * 100, stale
+}
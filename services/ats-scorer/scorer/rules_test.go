@@ -0,0 +1,47 @@
+package scorer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateRecencyWeightedSkillScore(t *testing.T) {
+	profiles := []SkillProfile{
+		{Skill: "go", LastUsed: 2024, Current: true},
+		{Skill: "java", LastUsed: 2016},
+		{Skill: "perl", LastUsed: 0},
+	}
+
+	tests := []struct {
+		name               string
+		matched            []string
+		requiresCurrentUse bool
+		referenceYear      int
+		wantScore          float64
+		wantStale          []string
+	}{
+		{"current skill", []string{"go"}, true, 2024, 50, []string{}},
+		// Java was last used 8 years before the reference year
+		{"stale skill", []string{"go", "java"}, true, 2024, 70, []string{"java"}},
+		{"same profiles five years earlier", []string{"go", "java"}, true, 2019, 92.5, []string{}},
+		{"undated skill", []string{"go", "perl"}, true, 2024, 87.5, []string{}},
+		{"skill without a profile", []string{"go", "rust"}, true, 2024, 87.5, []string{}},
+		{"current use not required", []string{"java", "perl"}, false, 2024, 100, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing := make([]string, 0)
+			if len(tt.matched) == 1 {
+				missing = append(missing, "kubernetes")
+			}
+			score, stale := calculateRecencyWeightedSkillScore(tt.matched, missing, profiles, tt.requiresCurrentUse, tt.referenceYear)
+			if score != tt.wantScore {
+				t.Errorf("score = %v, want %v", score, tt.wantScore)
+			}
+			if !reflect.DeepEqual(stale, tt.wantStale) {
+				t.Errorf("stale skills = %q, want %q", stale, tt.wantStale)
+			}
+		})
+	}
+}
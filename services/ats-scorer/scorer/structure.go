@@ -0,0 +1,55 @@
+package scorer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Block names the NLP service uses for content outside a recognized section
+const (
+	unknownSection = "unknown"
+	otherSection   = "other"
+)
+
+// SectionBlock mirrors a heading block from the NLP service
+type SectionBlock struct {
+	Name      string `json:"name"`
+	Heading   string `json:"heading"`
+	Content   string `json:"content"` // Lines under the heading, one per line
+	StartLine int    `json:"startLine"`
+}
+
+// checkSectionStructure warns about headings an ATS may not recognize and sections split across the resume
+func checkSectionStructure(blocks []SectionBlock) []string {
+	warnings := make([]string, 0)
+	lines := make(map[string][]string)
+	var order []string
+
+	for _, block := range blocks {
+		switch block.Name {
+		case unknownSection:
+			continue
+		case otherSection:
+			warnings = append(warnings, fmt.Sprintf(
+				"Heading %q (line %d) is not a standard section name, so an ATS may not know where its content belongs.",
+				block.Heading, block.StartLine))
+			continue
+		}
+
+		if _, seen := lines[block.Name]; !seen {
+			order = append(order, block.Name)
+		}
+		lines[block.Name] = append(lines[block.Name], strconv.Itoa(block.StartLine))
+	}
+
+	for _, name := range order {
+		if len(lines[name]) > 1 {
+			warnings = append(warnings, fmt.Sprintf(
+				"The %s section appears %d times (lines %s); some ATS systems only read one. Merge them under one heading.",
+				name, len(lines[name]), strings.Join(lines[name], ", ")))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,69 @@
+package scorer
+
+import (
+	"fmt"
+)
+
+// TitleAlignment mirrors the title and seniority comparison from the NLP service
+type TitleAlignment struct {
+	TargetTitle        string   `json:"targetTitle"`
+	TargetSeniority    string   `json:"targetSeniority"`
+	TargetRank         int      `json:"targetRank"`
+	CandidateTitle     string   `json:"candidateTitle"`
+	CandidateSeniority string   `json:"candidateSeniority"`
+	CandidateRank      int      `json:"candidateRank"`
+	Titles             []string `json:"titles"`
+	BestMatch          string   `json:"bestMatch"`
+	Similarity         float64  `json:"similarity"`
+	SeniorityGap       int      `json:"seniorityGap"` // Positive when the role is more senior than the candidate
+}
+
+// calculateTitleScore scores how well the candidate's titles fit the role, or returns
+// ok=false when the JD names no title to compare against
+func calculateTitleScore(alignment TitleAlignment) (score float64, ok bool) {
+	if alignment.TargetTitle == "" {
+		return 0, false
+	}
+
+	score = alignment.Similarity * 100
+
+	// A step up is a bigger risk than a step down, but both are flagged by recruiters
+	switch gap := alignment.SeniorityGap; {
+	case gap >= 2:
+		score -= 30
+	case gap == 1:
+		score -= 15
+	case gap == -1:
+		score -= 10
+	case gap <= -2:
+		score -= 20
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	if score > 100 {
+		score = 100
+	}
+	return score, true
+}
+
+// titleFeedback explains a weak title match or a seniority gap, or returns "" when the titles fit
+func titleFeedback(alignment TitleAlignment) string {
+	if alignment.TargetTitle == "" {
+		return ""
+	}
+
+	switch {
+	case alignment.SeniorityGap >= 2:
+		return fmt.Sprintf(" The role is %s level but your most recent title reads as %s; highlight the scope and ownership you have taken on.",
+			alignment.TargetSeniority, alignment.CandidateSeniority)
+	case alignment.SeniorityGap <= -2:
+		return fmt.Sprintf(" The role is %s level while your most recent title reads as %s; explain why this role fits your plans.",
+			alignment.TargetSeniority, alignment.CandidateSeniority)
+	case alignment.Similarity < 0.5:
+		return fmt.Sprintf(" None of your titles closely match \"%s\"; if your work matches the role, use its wording in your summary.",
+			alignment.TargetTitle)
+	}
+	return ""
+}
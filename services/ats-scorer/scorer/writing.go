@@ -0,0 +1,210 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ActionVerbs database of strong bullet openers by what they show, in past tense
+var ActionVerbs = map[string][]string{
+	"leadership": {"led", "directed", "managed", "supervised", "mentored", "coached", "coordinated", "headed",
+		"orchestrated", "oversaw", "spearheaded", "championed", "delegated", "hired", "recruited", "onboarded",
+		"trained", "guided", "mobilized", "chaired"},
+	"building": {"built", "developed", "designed", "created", "implemented", "engineered", "architected",
+		"launched", "shipped", "delivered", "deployed", "established", "founded", "introduced", "prototyped",
+		"programmed", "coded", "authored", "wrote", "constructed", "integrated", "configured", "automated",
+		"containerized", "migrated", "released", "set up", "initiated", "produced", "assembled"},
+	"improvement": {"improved", "optimized", "increased", "reduced", "decreased", "accelerated", "streamlined",
+		"enhanced", "refactored", "redesigned", "modernized", "upgraded", "simplified", "consolidated",
+		"overhauled", "revamped", "strengthened", "stabilized", "scaled", "cut", "boosted", "expanded", "grew",
+		"lowered", "minimized", "maximized", "eliminated", "transformed", "restructured", "hardened"},
+	"analysis": {"analyzed", "assessed", "audited", "benchmarked", "diagnosed", "evaluated", "investigated",
+		"measured", "modeled", "profiled", "researched", "forecasted", "identified", "quantified", "tested",
+		"validated", "verified", "monitored", "mapped", "surveyed", "troubleshot", "debugged", "resolved", "solved"},
+	"communication": {"presented", "documented", "negotiated", "persuaded", "collaborated", "partnered",
+		"facilitated", "advocated", "published", "reported", "briefed", "consulted", "advised", "educated",
+		"translated", "communicated", "demonstrated", "promoted", "pitched", "liaised"},
+	"achievement": {"achieved", "won", "earned", "exceeded", "surpassed", "secured", "awarded", "attained",
+		"completed", "generated", "saved", "captured", "closed", "outperformed", "recognized", "drove", "ranked"},
+}
+
+// irregularPast maps present-tense openers to the past tense used in ActionVerbs
+var irregularPast = map[string]string{
+	"lead": "led", "build": "built", "write": "wrote", "grow": "grew", "win": "won", "drive": "drove",
+	"oversee": "oversaw", "set": "set", "cut": "cut", "troubleshoot": "troubleshot", "earn": "earned",
+}
+
+// weakOpeners are bullet openings that describe duties instead of results
+var weakOpeners = []string{
+	"responsible for", "was responsible for", "helped with", "helped to", "helped", "worked on", "worked with",
+	"assisted with", "assisted in", "involved in", "was involved in", "participated in", "tasked with",
+	"duties included", "in charge of", "handled", "was part of", "part of", "did", "tried to", "attempted to",
+}
+
+var (
+	// passivePattern matches passive voice such as "was developed" or "were reviewed"
+	passivePattern = regexp.MustCompile(`(?i)\b(?:was|were|is|are|been|being|got)\s+(?:\w+ly\s+)?` +
+		`(\w+ed|built|made|done|given|led|run|written|taken|sent|shown|chosen|grown|driven|known|seen)\b`)
+	// pronounPattern matches first-person pronouns; "US" in capitals is the country
+	pronounPattern = regexp.MustCompile(`\b(?:I|[Mm]e|[Mm]y|[Mm]ine|[Mm]yself|[Ww]e|[Oo]ur|us)\b`)
+)
+
+// maxOpenerRepeats is how many bullets may start with the same verb before it reads as repetitive
+const maxOpenerRepeats = 2
+
+// actionVerbCategories indexes ActionVerbs by verb
+var actionVerbCategories = indexActionVerbs(ActionVerbs)
+
+func indexActionVerbs(verbs map[string][]string) map[string]string {
+	index := make(map[string]string)
+	for category, list := range verbs {
+		for _, verb := range list {
+			index[verb] = category
+		}
+	}
+	return index
+}
+
+// WritingAnalysis summarizes the writing quality of a list of bullets
+type WritingAnalysis struct {
+	Bullets         int
+	StrongOpeners   int            // Bullets starting with an action verb
+	Categories      map[string]int // Action verb categories used, by count
+	WeakOpeners     int
+	PassiveVoice    int
+	Pronouns        int
+	RepeatedOpeners int      // Verbs that open more than maxOpenerRepeats bullets
+	Details         []string // One suggestion per issue, in resume order
+}
+
+// IssueCount is the total number of writing issues found
+func (a WritingAnalysis) IssueCount() int {
+	return a.WeakOpeners + a.PassiveVoice + a.Pronouns + a.RepeatedOpeners
+}
+
+// openerVerb returns the action verb a bullet starts with, in past tense, or "" when it starts with none
+func openerVerb(bullet string) string {
+	words := strings.Fields(strings.ToLower(bullet))
+	if len(words) == 0 {
+		return ""
+	}
+	word := strings.Trim(words[0], ".,;:!?()\"'")
+	if len(words) > 1 && word == "set" && strings.Trim(words[1], ".,;:") == "up" {
+		return "set up"
+	}
+
+	// Try the word as written, then as a present-tense form such as "build", "builds" or "optimizes"
+	var candidates []string
+	for _, base := range []string{word, strings.TrimSuffix(word, "s"), strings.TrimSuffix(word, "es")} {
+		candidates = append(candidates, base, base+"ed", base+"d")
+		if past, ok := irregularPast[base]; ok {
+			candidates = append(candidates, past)
+		}
+		if strings.HasSuffix(base, "y") {
+			candidates = append(candidates, strings.TrimSuffix(base, "y")+"ied") // "simplify" -> "simplified"
+		}
+		if n := len(base); n > 2 {
+			candidates = append(candidates, base+base[n-1:]+"ed") // "ship" -> "shipped"
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, ok := actionVerbCategories[candidate]; ok {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// weakOpener returns the weak phrase a bullet starts with, or ""
+func weakOpener(bullet string) string {
+	lower := strings.ToLower(strings.TrimSpace(bullet))
+	for _, phrase := range weakOpeners {
+		if lower == phrase || strings.HasPrefix(lower, phrase+" ") {
+			return phrase
+		}
+	}
+	return ""
+}
+
+// analyzeWriting checks each bullet for a strong opener, weak phrasing, passive voice and
+// first-person pronouns, and the bullets together for verbs used too often
+func analyzeWriting(bullets []string) WritingAnalysis {
+	analysis := WritingAnalysis{
+		Bullets:    len(bullets),
+		Categories: make(map[string]int),
+		Details:    make([]string, 0),
+	}
+	openers := make(map[string]int)
+	var openerOrder []string
+
+	for _, bullet := range bullets {
+		quoted := shortenBullet(bullet)
+
+		if verb := openerVerb(bullet); verb != "" {
+			analysis.StrongOpeners++
+			analysis.Categories[actionVerbCategories[verb]]++
+			if openers[verb] == 0 {
+				openerOrder = append(openerOrder, verb)
+			}
+			openers[verb]++
+		} else if phrase := weakOpener(bullet); phrase != "" {
+			analysis.WeakOpeners++
+			analysis.Details = append(analysis.Details, fmt.Sprintf(
+				"Weak opening %q: start with an action verb that shows the result: %q", phrase, quoted))
+		}
+
+		if match := passivePattern.FindString(bullet); match != "" {
+			analysis.PassiveVoice++
+			analysis.Details = append(analysis.Details, fmt.Sprintf(
+				"Passive voice %q: say what you did in active voice: %q", match, quoted))
+		}
+
+		if pronouns := pronounPattern.FindAllString(bullet, -1); len(pronouns) > 0 {
+			analysis.Pronouns++
+			analysis.Details = append(analysis.Details, fmt.Sprintf(
+				"First-person pronoun %q: drop it and start with the verb: %q", pronouns[0], quoted))
+		}
+	}
+
+	for _, verb := range openerOrder {
+		if openers[verb] > maxOpenerRepeats {
+			analysis.RepeatedOpeners++
+			analysis.Details = append(analysis.Details, fmt.Sprintf(
+				"%d bullets start with %q: vary the verbs so each bullet stands out", openers[verb], verb))
+		}
+	}
+
+	// Bullets that all show the same kind of work undersell the rest
+	if analysis.StrongOpeners >= 4 && len(analysis.Categories) == 1 {
+		for category := range analysis.Categories {
+			analysis.Details = append(analysis.Details, fmt.Sprintf(
+				"Every bullet opens with a %s verb: add bullets that show other kinds of work, such as results you improved or people you led", category))
+		}
+	}
+
+	return analysis
+}
+
+// writingSummary describes the writing issues found, or returns "" when there are none
+func writingSummary(analysis WritingAnalysis) string {
+	counts := map[string]int{
+		"weak openings":          analysis.WeakOpeners,
+		"passive voice":          analysis.PassiveVoice,
+		"first-person pronouns":  analysis.Pronouns,
+		"repeated opening verbs": analysis.RepeatedOpeners,
+	}
+	var parts []string
+	for issue, count := range counts {
+		if count > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%d)", issue, count))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	sort.Strings(parts)
+	return " Writing issues: " + strings.Join(parts, ", ") + "."
+}
@@ -0,0 +1,105 @@
+package scorer
+
+import "testing"
+
+func TestOpenerVerb(t *testing.T) {
+	tests := []struct {
+		bullet string
+		want   string
+	}{
+		{"Led a team of five engineers", "led"},
+		{"Builds data pipelines in Spark", "built"},
+		{"Optimizes SQL queries", "optimized"},
+		{"Simplify the release process", "simplified"},
+		{"Shipped the v2 API", "shipped"},
+		{"Set up CI for every service", "set up"},
+		{"Troubleshoot production outages", "troubleshot"},
+		{"Responsible for code reviews", ""},
+		{"The API serves 2M users", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bullet, func(t *testing.T) {
+			if got := openerVerb(tt.bullet); got != tt.want {
+				t.Errorf("openerVerb = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeakOpener(t *testing.T) {
+	tests := []struct {
+		bullet string
+		want   string
+	}{
+		{"Responsible for code reviews", "responsible for"},
+		{"Was responsible for on-call", "was responsible for"},
+		{"Helped with the migration", "helped with"},
+		{"Worked on internal tooling", "worked on"},
+		{"Handled customer escalations", "handled"},
+		{"Handlebars templates for emails", ""},
+		{"Built the billing API", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bullet, func(t *testing.T) {
+			if got := weakOpener(tt.bullet); got != tt.want {
+				t.Errorf("weakOpener = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeWriting(t *testing.T) {
+	tests := []struct {
+		name    string
+		bullets []string
+		want    WritingAnalysis
+	}{
+		{
+			name:    "strong bullets",
+			bullets: []string{"Built the billing API", "Reduced latency by 40%", "Mentored two engineers"},
+			want:    WritingAnalysis{Bullets: 3, StrongOpeners: 3},
+		},
+		{
+			name:    "weak opening",
+			bullets: []string{"Worked on internal tooling"},
+			want:    WritingAnalysis{Bullets: 1, WeakOpeners: 1},
+		},
+		{
+			name:    "passive voice",
+			bullets: []string{"The API was redesigned by the team", "Dashboards were automatically generated"},
+			want:    WritingAnalysis{Bullets: 2, PassiveVoice: 2},
+		},
+		{
+			name:    "first-person pronouns",
+			bullets: []string{"I built our deploy pipeline", "Built tools for my team"},
+			want:    WritingAnalysis{Bullets: 2, StrongOpeners: 1, Pronouns: 2},
+		},
+		{
+			name:    "the US is not a pronoun",
+			bullets: []string{"Expanded sales into the US and Canada"},
+			want:    WritingAnalysis{Bullets: 1, StrongOpeners: 1},
+		},
+		{
+			name:    "verb repeated too often",
+			bullets: []string{"Built the API", "Built the CLI", "Built the dashboard"},
+			want:    WritingAnalysis{Bullets: 3, StrongOpeners: 3, RepeatedOpeners: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := analyzeWriting(tt.bullets)
+			if got.Bullets != tt.want.Bullets || got.StrongOpeners != tt.want.StrongOpeners ||
+				got.WeakOpeners != tt.want.WeakOpeners || got.PassiveVoice != tt.want.PassiveVoice ||
+				got.Pronouns != tt.want.Pronouns || got.RepeatedOpeners != tt.want.RepeatedOpeners {
+				t.Errorf("analyzeWriting = %+v, want counts %+v", got, tt.want)
+			}
+			if len(got.Details) != got.IssueCount() {
+				t.Errorf("%d details for %d issues: %q", len(got.Details), got.IssueCount(), got.Details)
+			}
+		})
+	}
+}
@@ -28,6 +28,9 @@ func main() {
 	// Analysis endpoint
 	app.Post("/analyze", nlp.HandleAnalyze)
 
+	// Certification name lookup, used by the gateway's what-if simulation
+	app.Post("/certifications", nlp.HandleCertifications)
+
 	port := getEnv("PORT", "8082")
 	log.Printf("NLP Service starting on port %s", port)
 
@@ -0,0 +1,237 @@
+package nlp
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Certification is a professional certification with the names it goes by
+type Certification struct {
+	Name    string   `json:"name"`
+	Issuer  string   `json:"issuer"`
+	Aliases []string `json:"aliases"` // Other spellings and abbreviations; all-caps aliases match case-sensitively
+}
+
+// CertificationCatalog database of known certifications
+var CertificationCatalog = []Certification{
+	// Cloud
+	{Name: "AWS Certified Solutions Architect - Associate", Issuer: "Amazon Web Services",
+		Aliases: []string{"AWS Certified Solutions Architect", "AWS Solutions Architect Associate", "AWS Solutions Architect", "AWS SAA", "SAA-C03", "SAA-C02"}},
+	{Name: "AWS Certified Solutions Architect - Professional", Issuer: "Amazon Web Services",
+		Aliases: []string{"AWS Certified Solutions Architect Professional", "AWS Solutions Architect Professional", "AWS SAP", "SAP-C02"}},
+	{Name: "AWS Certified Developer - Associate", Issuer: "Amazon Web Services",
+		Aliases: []string{"AWS Certified Developer", "AWS Developer Associate", "DVA-C02"}},
+	{Name: "AWS Certified SysOps Administrator - Associate", Issuer: "Amazon Web Services",
+		Aliases: []string{"AWS Certified SysOps Administrator", "AWS SysOps Administrator", "SOA-C02"}},
+	{Name: "AWS Certified DevOps Engineer - Professional", Issuer: "Amazon Web Services",
+		Aliases: []string{"AWS Certified DevOps Engineer", "AWS DevOps Engineer Professional", "DOP-C02"}},
+	{Name: "AWS Certified Cloud Practitioner", Issuer: "Amazon Web Services",
+		Aliases: []string{"AWS Cloud Practitioner", "CLF-C02"}},
+	{Name: "AWS Certified Security - Specialty", Issuer: "Amazon Web Services",
+		Aliases: []string{"AWS Certified Security Specialty", "AWS Security Specialty", "SCS-C02"}},
+	{Name: "Microsoft Certified: Azure Administrator Associate", Issuer: "Microsoft",
+		Aliases: []string{"Azure Administrator Associate", "Azure Administrator", "AZ-104"}},
+	{Name: "Microsoft Certified: Azure Solutions Architect Expert", Issuer: "Microsoft",
+		Aliases: []string{"Azure Solutions Architect Expert", "Azure Solutions Architect", "AZ-305"}},
+	{Name: "Microsoft Certified: Azure Fundamentals", Issuer: "Microsoft",
+		Aliases: []string{"Azure Fundamentals", "AZ-900"}},
+	{Name: "Google Cloud Professional Cloud Architect", Issuer: "Google Cloud",
+		Aliases: []string{"Professional Cloud Architect", "GCP Cloud Architect", "Google Cloud Architect"}},
+	{Name: "Google Cloud Associate Cloud Engineer", Issuer: "Google Cloud",
+		Aliases: []string{"Associate Cloud Engineer", "GCP Associate Cloud Engineer"}},
+
+	// Containers & infrastructure
+	{Name: "Certified Kubernetes Administrator", Issuer: "Cloud Native Computing Foundation",
+		Aliases: []string{"CKA"}},
+	{Name: "Certified Kubernetes Application Developer", Issuer: "Cloud Native Computing Foundation",
+		Aliases: []string{"CKAD"}},
+	{Name: "Certified Kubernetes Security Specialist", Issuer: "Cloud Native Computing Foundation",
+		Aliases: []string{"CKS"}},
+	{Name: "HashiCorp Certified: Terraform Associate", Issuer: "HashiCorp",
+		Aliases: []string{"Terraform Associate", "HashiCorp Terraform Associate"}},
+	{Name: "Red Hat Certified Engineer", Issuer: "Red Hat",
+		Aliases: []string{"RHCE"}},
+	{Name: "Red Hat Certified System Administrator", Issuer: "Red Hat",
+		Aliases: []string{"RHCSA"}},
+
+	// Security
+	{Name: "Certified Information Systems Security Professional", Issuer: "ISC2",
+		Aliases: []string{"CISSP"}},
+	{Name: "Certified Information Security Manager", Issuer: "ISACA",
+		Aliases: []string{"CISM"}},
+	{Name: "Certified Information Systems Auditor", Issuer: "ISACA",
+		Aliases: []string{"CISA"}},
+	{Name: "CompTIA Security+", Issuer: "CompTIA",
+		Aliases: []string{"Security+", "Security Plus", "Sec+"}},
+	{Name: "CompTIA Network+", Issuer: "CompTIA",
+		Aliases: []string{"Network+", "Network Plus"}},
+	{Name: "CompTIA A+", Issuer: "CompTIA",
+		Aliases: []string{"A+ certification", "A+ certified"}},
+	{Name: "Offensive Security Certified Professional", Issuer: "OffSec",
+		Aliases: []string{"OSCP"}},
+	{Name: "Certified Ethical Hacker", Issuer: "EC-Council",
+		Aliases: []string{"CEH"}},
+	{Name: "GIAC Security Essentials", Issuer: "GIAC",
+		Aliases: []string{"GSEC"}},
+	{Name: "Certified Cloud Security Professional", Issuer: "ISC2",
+		Aliases: []string{"CCSP"}},
+
+	// Networking
+	{Name: "Cisco Certified Network Associate", Issuer: "Cisco",
+		Aliases: []string{"CCNA"}},
+	{Name: "Cisco Certified Network Professional", Issuer: "Cisco",
+		Aliases: []string{"CCNP"}},
+
+	// Project management & process
+	{Name: "Project Management Professional", Issuer: "Project Management Institute",
+		Aliases: []string{"PMP"}},
+	{Name: "Certified ScrumMaster", Issuer: "Scrum Alliance",
+		Aliases: []string{"Certified Scrum Master", "CSM"}},
+	{Name: "Professional Scrum Master", Issuer: "Scrum.org",
+		Aliases: []string{"PSM I", "PSM II", "PSM"}},
+	{Name: "ITIL Foundation", Issuer: "Axelos",
+		Aliases: []string{"ITIL v4 Foundation", "ITIL 4 Foundation", "ITIL Certified"}},
+
+	// Data
+	{Name: "Databricks Certified Data Engineer Associate", Issuer: "Databricks",
+		Aliases: []string{"Databricks Data Engineer Associate"}},
+	{Name: "Oracle Certified Professional, Java SE Programmer", Issuer: "Oracle",
+		Aliases: []string{"OCP Java", "Oracle Certified Java Programmer", "OCPJP"}},
+}
+
+// unfinishedCertificationPattern marks a certification that has not been earned yet, e.g. "CKA (in progress)"
+var unfinishedCertificationPattern = regexp.MustCompile(`(?i)^[\s(,\-–:]*(in progress|in-progress|expected|pending|ongoing|scheduled|planned)\b`)
+
+// certificationAlias is one compiled name of a catalog certification
+type certificationAlias struct {
+	certification string
+	pattern       *regexp.Regexp
+}
+
+// certificationAliases holds the compiled names and aliases of every catalog certification
+var certificationAliases = compileCertificationAliases(CertificationCatalog)
+
+func compileCertificationAliases(catalog []Certification) []certificationAlias {
+	var aliases []certificationAlias
+	for _, certification := range catalog {
+		for _, name := range append([]string{certification.Name}, certification.Aliases...) {
+			// Acronyms such as "CKA" or "PMP" only match in capitals to avoid ordinary words
+			flags := `(?i)`
+			if name == strings.ToUpper(name) {
+				flags = ``
+			}
+			// Dashes, colons and commas between words are optional, e.g. "Architect – Professional"
+			words := strings.FieldsFunc(name, isCertificationSeparator)
+			for i, word := range words {
+				words[i] = regexp.QuoteMeta(word)
+			}
+			aliases = append(aliases, certificationAlias{
+				certification: certification.Name,
+				pattern:       regexp.MustCompile(flags + strings.Join(words, `[\s\-–—:,]+`)),
+			})
+		}
+	}
+	return aliases
+}
+
+// isCertificationSeparator reports whether r separates the words of a certification name
+func isCertificationSeparator(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("-–—:,", r)
+}
+
+// certificationMatch is one certification mention in text
+type certificationMatch struct {
+	certification string
+	start, end    int
+}
+
+// ExtractCertifications finds catalog certifications in text, in catalog order.
+// When names overlap, such as "AWS Certified Solutions Architect" inside the professional
+// certification's name, the longest name wins. Unless includeUnearned is set, certifications
+// mentioned as being studied for or still in progress are skipped.
+func ExtractCertifications(text string, includeUnearned bool) []string {
+	found := make(map[string]bool)
+	for _, m := range findCertifications(text, includeUnearned) {
+		found[m.certification] = true
+	}
+
+	certifications := make([]string, 0, len(found))
+	for _, certification := range CertificationCatalog {
+		if found[certification.Name] {
+			certifications = append(certifications, certification.Name)
+		}
+	}
+	return certifications
+}
+
+// CertificationTerms maps each earned certification in text to the ways text writes it, such as
+// "CKA" for the Certified Kubernetes Administrator, in order of appearance
+func CertificationTerms(text string) map[string][]string {
+	terms := make(map[string][]string)
+	for _, m := range findCertifications(text, false) {
+		term := text[m.start:m.end]
+		if !slices.Contains(terms[m.certification], term) {
+			terms[m.certification] = append(terms[m.certification], term)
+		}
+	}
+	return terms
+}
+
+// CanonicalCertification returns the catalog name of a certification name or alias, or an
+// empty string when the name is not in the catalog
+func CanonicalCertification(name string) string {
+	if matches := findCertifications(strings.TrimSpace(name), true); len(matches) > 0 {
+		return matches[0].certification
+	}
+	return ""
+}
+
+// findCertifications returns the certification mentions in text in order of appearance,
+// keeping the longest name where names overlap
+func findCertifications(text string, includeUnearned bool) []certificationMatch {
+	var matches []certificationMatch
+
+	for _, alias := range certificationAliases {
+		for _, loc := range alias.pattern.FindAllStringIndex(text, -1) {
+			before, _ := utf8.DecodeLastRuneInString(text[:loc[0]])
+			after, _ := utf8.DecodeRuneInString(text[loc[1]:])
+			if isSkillRune(before) || isSkillRune(after) {
+				continue
+			}
+			matches = append(matches, certificationMatch{certification: alias.certification, start: loc[0], end: loc[1]})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+
+	kept := make([]certificationMatch, 0, len(matches))
+	lastEnd := 0
+	for _, m := range matches {
+		if m.start < lastEnd {
+			continue
+		}
+		lastEnd = m.end
+
+		if !includeUnearned {
+			if classifyMention(text, m.start) != MentionPositive || unfinishedCertificationPattern.MatchString(text[m.end:]) {
+				continue
+			}
+		}
+		kept = append(kept, m)
+	}
+	return kept
+}
+
+// CompareCertifications finds the JD's certifications the resume has and lacks
+func CompareCertifications(resumeCertifications, jdCertifications []string) (matched, missing []string) {
+	return CompareSkills(resumeCertifications, jdCertifications)
+}
@@ -0,0 +1,56 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalCertification(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"AWS SAA", "AWS Certified Solutions Architect - Associate"},
+		{"CISSP", "Certified Information Systems Security Professional"},
+		{"  Certified Kubernetes Administrator ", "Certified Kubernetes Administrator"},
+		{"Underwater Basket Weaving", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalCertification(tt.name); got != tt.want {
+				t.Errorf("CanonicalCertification(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCertificationTerms(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want map[string][]string
+	}{
+		{
+			name: "abbreviation and full name",
+			text: "CERTIFICATIONS\nCKA, 2022\nAWS SAA (2021)\n\nEXPERIENCE\nRan clusters as a Certified Kubernetes Administrator",
+			want: map[string][]string{
+				"Certified Kubernetes Administrator":            {"CKA", "Certified Kubernetes Administrator"},
+				"AWS Certified Solutions Architect - Associate": {"AWS SAA"},
+			},
+		},
+		{
+			name: "certification still in progress",
+			text: "Currently studying for the CKA",
+			want: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CertificationTerms(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CertificationTerms() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
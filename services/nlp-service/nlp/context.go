@@ -0,0 +1,241 @@
+package nlp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Skill mention classifications
+const (
+	MentionPositive   = "positive"
+	MentionNegated    = "negated"
+	MentionLearning   = "learning"
+	MentionHistorical = "historical"
+)
+
+// Context window sizes
+const (
+	// cueWindowWords is how many words before a mention are searched for cues
+	cueWindowWords = 8
+	// maxContextRunes caps the length of the returned context snippet
+	maxContextRunes = 200
+)
+
+// SkillMention represents a single occurrence of a skill with its surrounding context
+type SkillMention struct {
+	Skill          string `json:"skill"`
+	Classification string `json:"classification"`
+	Context        string `json:"context"`
+	Start          int    `json:"start"` // UTF-16 offset of the mention in the text, as JavaScript indexes strings
+	End            int    `json:"end"`
+
+	byteStart, byteEnd int
+}
+
+// mentionCue is a phrase that changes how a following skill mention should be read
+type mentionCue struct {
+	classification string
+	pattern        *regexp.Regexp
+	// breakers end the cue's scope, e.g. "to" in "migrated from PHP to Go"
+	breakers *regexp.Regexp
+	// governs, when set, must match all the text between the cue and the mention, so the cue
+	// applies only to a skill it directly modifies
+	governs *regexp.Regexp
+}
+
+// clauseBreakers end the scope of every cue
+const clauseBreakers = `but|however|although|though|while|whereas|yet`
+
+// skillList matches skills listed before the mention when the list ends in "or", e.g. "Java, Go or "
+// in "no Java, Go or Rust". A comma alone ends the clause: "no downtime, Kubernetes rollouts".
+const skillList = `(?:(?:[\w.+#/-]+\s*,\s*){0,5}[\w.+#/-]+\s*,?\s+(?:or|nor)\s+)?`
+
+// negationLink is what may sit between a negation and the skill it negates: nothing, as in
+// "without Docker", or a link such as "experience with" in "no professional experience with Go"
+var negationLink = regexp.MustCompile(`^\s*(?:of\s+)?(?:(?:any|prior|professional|hands-on|direct|real|much|commercial|production)\s+)*` +
+	`(?:(?:experience|exposure|knowledge|background|familiarity|worked|work|used|use|using|know)\s+(?:(?:with|in|of|on)\s+)?)?` +
+	`(?:the\s+)?` + skillList + `$`)
+
+var mentionCues = []mentionCue{
+	{
+		classification: MentionNegated,
+		pattern:        regexp.MustCompile(`\b(no|not|never|without|lack|lacking|lacks|none|unfamiliar with|unfamiliar)\b|n't\b`),
+		breakers:       regexp.MustCompile(`\b(` + clauseBreakers + `)\b`),
+		governs:        negationLink,
+	},
+	{
+		classification: MentionLearning,
+		pattern: regexp.MustCompile(`\b(learning|familiar with|familiarity with|exposure to|exposed to|basic knowledge of|basic understanding of|` +
+			`beginner|studying|introductory|coursework in|interested in|eager to learn|some experience with|` +
+			`pursuing|preparing for|working towards|working toward)\b`),
+		breakers: regexp.MustCompile(`\b(` + clauseBreakers + `)\b`),
+	},
+	{
+		classification: MentionHistorical,
+		pattern: regexp.MustCompile(`\b(migrated away from|migrated from|migrating from|moved away from|moved off|moved from|` +
+			`replaced|replacing|deprecated|formerly|previously used|phased out|sunset|decommissioned|retired)\b`),
+		breakers: regexp.MustCompile(`\b(` + clauseBreakers + `|to|into|with|by|in favor of|towards|for)\b`),
+	},
+}
+
+// ExtractSkillMentions finds every skill occurrence in text and classifies its context.
+// A skill inside a longer skill's match, such as "spring" in "spring boot", is not a mention.
+func ExtractSkillMentions(text string) []SkillMention {
+	mentions := make([]SkillMention, 0)
+	claimed := make([]bool, len(text))
+
+	// Longer skills first so "spring boot" claims its text before "spring"
+	skills := allSkills()
+	sort.SliceStable(skills, func(i, j int) bool {
+		return len(skills[i]) > len(skills[j])
+	})
+
+	for _, skill := range skills {
+		for _, loc := range findSkillOccurrences(text, skill) {
+			if claimed[loc[0]] || claimed[loc[1]-1] {
+				continue
+			}
+			for i := loc[0]; i < loc[1]; i++ {
+				claimed[i] = true
+			}
+
+			mentions = append(mentions, SkillMention{
+				Skill:          skill,
+				Classification: classifyMention(text, loc[0]),
+				Context:        mentionContext(text, loc[0], loc[1]),
+				Start:          utf16Offset(text, loc[0]),
+				End:            utf16Offset(text, loc[1]),
+				byteStart:      loc[0],
+				byteEnd:        loc[1],
+			})
+		}
+	}
+
+	// Claimed spans don't overlap, so the start alone gives document order
+	sort.Slice(mentions, func(i, j int) bool {
+		return mentions[i].Start < mentions[j].Start
+	})
+
+	return mentions
+}
+
+// PositiveSkills returns the skills that have at least one positive mention
+func PositiveSkills(mentions []SkillMention) []string {
+	positive := make(map[string]bool)
+	for _, mention := range mentions {
+		if mention.Classification == MentionPositive {
+			positive[mention.Skill] = true
+		}
+	}
+
+	skills := make([]string, 0, len(positive))
+	for _, skill := range allSkills() {
+		if positive[skill] {
+			skills = append(skills, skill)
+			delete(positive, skill)
+		}
+	}
+
+	return skills
+}
+
+// classifyMention inspects the words before a mention for negation, learning or historical cues
+func classifyMention(text string, start int) string {
+	window := strings.ToLower(text[clauseStart(text, start):start])
+
+	// Only the last few words can modify the mention
+	words := strings.Fields(window)
+	if len(words) > cueWindowWords {
+		window = strings.Join(words[len(words)-cueWindowWords:], " ")
+	}
+
+	classification := MentionPositive
+	nearest := -1
+
+	for _, cue := range mentionCues {
+		locs := cue.pattern.FindAllStringIndex(window, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		last := locs[len(locs)-1]
+		if cue.breakers.MatchString(window[last[1]:]) {
+			continue
+		}
+		if cue.governs != nil && !cue.governs.MatchString(window[last[1]:]) {
+			continue
+		}
+
+		// The cue closest to the mention wins
+		if last[1] > nearest {
+			nearest = last[1]
+			classification = cue.classification
+		}
+	}
+
+	return classification
+}
+
+// clauseStart finds the start of the sentence or clause containing offset
+func clauseStart(text string, offset int) int {
+	for i := offset - 1; i >= 0; i-- {
+		switch text[i] {
+		case '\n', ';', '!', '?':
+			return i + 1
+		case '.':
+			// Ignore dots inside tokens such as "node.js"
+			if i+1 < len(text) && (text[i+1] == ' ' || text[i+1] == '\n') {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+// clauseEnd finds the end of the sentence or clause containing offset
+func clauseEnd(text string, offset int) int {
+	for i := offset; i < len(text); i++ {
+		switch text[i] {
+		case '\n', ';', '!', '?':
+			return i
+		case '.':
+			if i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\n' {
+				return i + 1
+			}
+		}
+	}
+	return len(text)
+}
+
+// utf16Offset converts a byte offset in text to the UTF-16 offset the frontend highlights by;
+// characters outside the Basic Multilingual Plane, such as emoji, take two units
+func utf16Offset(text string, byteOffset int) int {
+	return len(utf16.Encode([]rune(text[:byteOffset])))
+}
+
+// mentionContext returns the sentence around a mention, trimmed to maxContextRunes
+func mentionContext(text string, start, end int) string {
+	from := clauseStart(text, start)
+	to := clauseEnd(text, end)
+	context := strings.TrimSpace(text[from:to])
+
+	runes := []rune(context)
+	if len(runes) <= maxContextRunes {
+		return context
+	}
+
+	// Center the window on the mention
+	offset := utf8.RuneCountInString(strings.TrimLeft(text[from:start], " \t"))
+	begin := offset - maxContextRunes/2
+	if begin < 0 {
+		begin = 0
+	}
+	if begin+maxContextRunes > len(runes) {
+		begin = len(runes) - maxContextRunes
+	}
+
+	return "..." + strings.TrimSpace(string(runes[begin:begin+maxContextRunes])) + "..."
+}
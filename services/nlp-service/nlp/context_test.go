@@ -0,0 +1,73 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyMention(t *testing.T) {
+	tests := []struct {
+		text  string
+		skill string
+		want  string
+	}{
+		// Negation that governs the skill
+		{"No experience with Kubernetes", "kubernetes", MentionNegated},
+		{"Deployed services without Docker", "docker", MentionNegated},
+		{"Never used Terraform in production", "terraform", MentionNegated},
+		{"I haven't worked with Scala", "scala", MentionNegated},
+		{"No professional experience in Java, Kotlin or Scala", "scala", MentionNegated},
+		{"Lack of experience with AWS", "aws", MentionNegated},
+		{"Unfamiliar with Angular", "angular", MentionNegated},
+
+		// Negation of something else in the clause
+		{"Reduced latency with no downtime using Kubernetes", "kubernetes", MentionPositive},
+		{"Shipped the migration without a single outage on AWS", "aws", MentionPositive},
+		{"Not only built the API but also maintained it in Django", "django", MentionPositive},
+		{"Never missed a release deadline. Led the team using Scrum", "scrum", MentionPositive},
+		{"Built APIs without Docker, but deployed on Kubernetes", "kubernetes", MentionPositive},
+		{"No downtime, Kubernetes rollouts every week", "kubernetes", MentionPositive},
+
+		// Other cues
+		{"Currently learning Rust", "rust", MentionLearning},
+		{"Migrated from PHP to Go", "php", MentionHistorical},
+		{"Migrated from PHP to Go", "go", MentionPositive},
+		{"Built data pipelines in Python", "python", MentionPositive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text+"/"+tt.skill, func(t *testing.T) {
+			var got string
+			for _, mention := range ExtractSkillMentions(tt.text) {
+				if mention.Skill == tt.skill {
+					got = mention.Classification
+					break
+				}
+			}
+			if got != tt.want {
+				t.Errorf("%q in %q classified %q, want %q", tt.skill, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositiveSkillsLongestMatch(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Built services with Spring Boot and GitHub Actions", []string{"spring boot", "github actions"}},
+		{"Built a React.js dashboard", []string{"react.js"}},
+		{"Used Spring and GitHub", []string{"spring", "github"}},
+		{"Deployed on Google Cloud with Node.js", []string{"node.js", "google cloud"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := PositiveSkills(ExtractSkillMentions(tt.text))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PositiveSkills(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package nlp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Degree levels, lowest first
+const (
+	DegreeHighSchool = "high school"
+	DegreeAssociate  = "associate"
+	DegreeBachelor   = "bachelor"
+	DegreeMaster     = "master"
+	DegreeDoctorate  = "doctorate"
+)
+
+// maxFieldWords is the longest field of study kept
+const maxFieldWords = 6
+
+// degreeLevel recognizes the ways one degree level is written
+type degreeLevel struct {
+	level   string
+	rank    int
+	pattern *regexp.Regexp
+}
+
+// degreeLevels are checked highest first so "Master of Science" never reads as a lesser degree.
+// Two-letter abbreviations ("BS", "MS") need capitals and a following "in", "of", "/" or ","
+// so "MS Office" or "BA" in a sentence are not read as degrees.
+var degreeLevels = []degreeLevel{
+	{DegreeDoctorate, 5, regexp.MustCompile(`(?i)\b(ph\.?\s?d\b\.?|doctorate|doctoral degree|doctor of (?:philosophy|science|engineering)|d\.?phil\b)`)},
+	{DegreeMaster, 4, regexp.MustCompile(`\b((?i:master['’]?s?\b|m\.\s?sc\b\.?|msc\b|m\.?\s?tech\b|m\.?\s?eng\b|mba\b|m\.s\.|m\.a\.))|\b(MS|MA|ME)(?:\s+(?i:in|of)\b|\s*[/,])`)},
+	{DegreeBachelor, 3, regexp.MustCompile(`\b((?i:bachelor['’]?s?\b|b\.\s?sc\b\.?|bsc\b|b\.?\s?tech\b|b\.?\s?eng\b|b\.s\.|b\.a\.|b\.e\.|undergraduate degree|four[- ]year degree))|\b(BS|BA|BE)(?:\s+(?i:in|of)\b|\s*[/,])`)},
+	{DegreeAssociate, 2, regexp.MustCompile(`(?i)\b(associate['’]?s? degree|associate of (?:arts|science|applied science)|a\.a\.s?\.|a\.s\.)`)},
+	{DegreeHighSchool, 1, regexp.MustCompile(`(?i)\b(high school|secondary school|ged)\b`)},
+}
+
+var (
+	// fieldPattern captures the field of study following a degree, e.g. "of Science in Computer Science"
+	fieldPattern = regexp.MustCompile(`(?i)^['’s.\s]*(?:degree\s+)?(?:\([^)]*\)\s*)?(?:of\s+(?:science|arts|engineering|technology|applied science|business administration)\s+)?(?:in|of)\s+(.+)`)
+	// fieldEnd marks where a field of study ends on a resume line
+	fieldEnd = regexp.MustCompile(`(?i)\s*(?:[,;|(]|\s[-–—]\s|\s(?:from|at)\s|\b(?:19|20)\d{2}\b|\bgpa\b)`)
+	// requirementFieldEnd marks where the fields in a JD requirement end
+	requirementFieldEnd = regexp.MustCompile(`(?i)\s*(?:[;(.]\s|[;(]|\.$|\bor\s+(?:an?\s+)?(?:equivalent|related|similar|relevant)\b|\bwith\b|\bplus\b|\band\s+\d|\s(?:is|are|preferred|required|desired|strongly)\b)`)
+	// fieldSeparator splits a list of acceptable fields
+	fieldSeparator = regexp.MustCompile(`(?i)\s*(?:,|/|\bor\b|\band\b)\s*`)
+	// institutionPattern finds school names
+	institutionPattern = regexp.MustCompile(`(?i)\b(university|college|institute|school|academy|polytechnic|universidad|universität|université|universidade|hochschule|iit|mit)\b`)
+	// institutionSeparator splits a line into candidate institution names
+	institutionSeparator = regexp.MustCompile(`\s*(?:[,;|()–—]|\s-\s|\s(?:from|at)\s)\s*`)
+	// yearPattern matches a four digit year
+	yearPattern = regexp.MustCompile(`\b((?:19|20)\d{2})\b`)
+	// gpaPattern matches a GPA with an optional scale, e.g. "GPA: 3.8/4.0"
+	gpaPattern = regexp.MustCompile(`(?i)\b(?:gpa|cgpa|grade point average)\b[:\s]*(\d{1,2}(?:\.\d{1,2})?)(?:\s*(?:/|out of)\s*(\d{1,2}(?:\.\d{1,2})?))?`)
+	// preferredPattern marks a JD line as a preference rather than a requirement
+	preferredPattern = regexp.MustCompile(`(?i)\b(preferred|a plus|nice to have|bonus|desired|desirable|ideally|advantage)\b`)
+	// equivalentPattern marks a JD requirement that accepts experience instead of the degree
+	equivalentPattern = regexp.MustCompile(`(?i)\b(equivalent|in lieu of|or (?:relevant|related|comparable) (?:work )?experience)\b`)
+	// genericFields are placeholders rather than real fields of study
+	genericFields = map[string]bool{"a related field": true, "related field": true, "a relevant field": true,
+		"relevant field": true, "a similar field": true, "similar": true, "related": true, "equivalent": true, "a technical field": true}
+)
+
+// Degree is an education entry found in the resume
+type Degree struct {
+	Level          string  `json:"level"`
+	Rank           int     `json:"rank"`   // 1 (high school) to 5 (doctorate)
+	Degree         string  `json:"degree"` // Degree as written, e.g. "B.S."
+	Field          string  `json:"field"`
+	Institution    string  `json:"institution"`
+	GraduationYear int     `json:"graduationYear"` // 0 when unknown
+	GPA            float64 `json:"gpa"`            // 0 when not listed
+	GPAScale       float64 `json:"gpaScale"`
+}
+
+// EducationRequirement is the degree a job description asks for
+type EducationRequirement struct {
+	Level      string   `json:"level"` // Lowest acceptable level
+	Rank       int      `json:"rank"`
+	Fields     []string `json:"fields"`     // Acceptable fields of study, empty when any field will do
+	Equivalent bool     `json:"equivalent"` // Equivalent experience is accepted instead
+	Required   bool     `json:"required"`   // False when the degree is only preferred
+	Text       string   `json:"text"`       // JD line the requirement comes from
+}
+
+// findDegree returns the highest degree level mentioned in line and where its match ends
+func findDegree(line string) (level degreeLevel, written string, end int, ok bool) {
+	for _, candidate := range degreeLevels {
+		if loc := candidate.pattern.FindStringSubmatchIndex(line); loc != nil {
+			// The written degree is whichever alternative group matched
+			start, stop := loc[2], loc[3]
+			if start < 0 {
+				start, stop = loc[4], loc[5]
+			}
+			return candidate, strings.TrimSpace(line[start:stop]), stop, true
+		}
+	}
+	return degreeLevel{}, "", 0, false
+}
+
+// ExtractDegrees finds the degrees listed in the education section, or anywhere in the
+// resume when it has no education section
+func ExtractDegrees(text, lang string) []Degree {
+	lines := sectionLines(text, "education", lang)
+	if len(lines) == 0 {
+		lines = strings.Split(text, "\n")
+	}
+
+	degrees := make([]Degree, 0)
+	seen := make(map[string]bool)
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		level, written, end, ok := findDegree(line)
+		if !ok {
+			continue
+		}
+
+		degree := Degree{
+			Level:  level.level,
+			Rank:   level.rank,
+			Degree: written,
+			Field:  degreeField(line[end:]),
+		}
+
+		// Year and GPA are often on the following line, the institution on the line before or after
+		nearby := []string{line}
+		if i+1 < len(lines) && !isDegreeLine(lines[i+1]) {
+			nearby = append(nearby, lines[i+1])
+		}
+		for _, candidate := range nearby {
+			if degree.Institution == "" {
+				degree.Institution = findInstitution(candidate)
+			}
+			if degree.GraduationYear == 0 {
+				degree.GraduationYear = latestYear(candidate)
+			}
+			if degree.GPA == 0 {
+				degree.GPA, degree.GPAScale = findGPA(candidate)
+			}
+		}
+		if degree.Institution == "" && i > 0 && !isDegreeLine(lines[i-1]) {
+			degree.Institution = findInstitution(lines[i-1])
+		}
+
+		key := degree.Level + "|" + strings.ToLower(degree.Field) + "|" + strings.ToLower(degree.Institution)
+		if !seen[key] {
+			seen[key] = true
+			degrees = append(degrees, degree)
+		}
+	}
+
+	return degrees
+}
+
+// degreeField extracts the field of study from the text following a degree on a resume line
+func degreeField(rest string) string {
+	match := fieldPattern.FindStringSubmatch(rest)
+	if match == nil {
+		return ""
+	}
+	field := match[1]
+	if loc := fieldEnd.FindStringIndex(field); loc != nil {
+		field = field[:loc[0]]
+	}
+	return limitWords(strings.Trim(field, " .:-"), maxFieldWords)
+}
+
+// findInstitution returns the part of a line naming a school
+func findInstitution(line string) string {
+	for _, part := range institutionSeparator.Split(line, -1) {
+		part = strings.Trim(part, " .:")
+		// Skip the degree itself, but keep schools named after one, e.g. "Springfield High School"
+		if institutionPattern.MatchString(part) && !isDegreeLine(part) {
+			return part
+		}
+	}
+	return ""
+}
+
+// isDegreeLine reports whether text starts with a degree
+func isDegreeLine(text string) bool {
+	text = strings.TrimSpace(text)
+	for _, level := range degreeLevels {
+		if loc := level.pattern.FindStringIndex(text); loc != nil && loc[0] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// latestYear returns the latest year on a line, usually the graduation year
+func latestYear(line string) int {
+	latest := 0
+	for _, match := range yearPattern.FindAllString(line, -1) {
+		if year, _ := strconv.Atoi(match); year > latest {
+			latest = year
+		}
+	}
+	return latest
+}
+
+// findGPA returns the GPA on a line and its scale, assuming 4.0 when none is given
+func findGPA(line string) (float64, float64) {
+	match := gpaPattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, 0
+	}
+	gpa, _ := strconv.ParseFloat(match[1], 64)
+	scale := 4.0
+	if match[2] != "" {
+		scale, _ = strconv.ParseFloat(match[2], 64)
+	} else if gpa > 4 {
+		scale = 10
+	}
+	return gpa, scale
+}
+
+// limitWords keeps at most n words of text
+func limitWords(text string, n int) string {
+	words := strings.Fields(text)
+	if len(words) > n {
+		words = words[:n]
+	}
+	return strings.Join(words, " ")
+}
+
+// ExtractEducationRequirement finds the degree a job description asks for. Required lines win
+// over preferred ones, and when a line accepts several levels ("BS or MS") the lowest counts.
+// It returns nil when the JD does not mention a degree.
+func ExtractEducationRequirement(jdText string) *EducationRequirement {
+	var best *EducationRequirement
+
+	for _, line := range strings.Split(jdText, "\n") {
+		line = strings.TrimSpace(line)
+		requirement := lineRequirement(line)
+		if requirement == nil {
+			continue
+		}
+
+		switch {
+		case best == nil,
+			requirement.Required && !best.Required,
+			requirement.Required == best.Required && requirement.Rank < best.Rank:
+			best = requirement
+		}
+	}
+
+	return best
+}
+
+// lineRequirement reads the lowest degree level and acceptable fields from one JD line
+func lineRequirement(line string) *EducationRequirement {
+	var requirement *EducationRequirement
+
+	for _, level := range degreeLevels {
+		for _, loc := range level.pattern.FindAllStringSubmatchIndex(line, -1) {
+			end := loc[3]
+			if loc[2] < 0 {
+				end = loc[5]
+			}
+			if requirement == nil || level.rank < requirement.Rank {
+				requirement = &EducationRequirement{Level: level.level, Rank: level.rank}
+			}
+			if fields := requirementFields(line[end:]); len(fields) > 0 && len(requirement.Fields) == 0 {
+				requirement.Fields = fields
+			}
+		}
+	}
+
+	if requirement == nil {
+		return nil
+	}
+	if requirement.Fields == nil {
+		requirement.Fields = make([]string, 0)
+	}
+	requirement.Equivalent = equivalentPattern.MatchString(line)
+	requirement.Required = !preferredPattern.MatchString(line)
+	requirement.Text = line
+	return requirement
+}
+
+// requirementFields splits the fields of study listed after a JD degree, e.g.
+// "in Computer Science, Engineering or a related field"
+func requirementFields(rest string) []string {
+	// Skip past other degrees in the same list, e.g. the "MS" in "BS/MS in Computer Science"
+	if idx := strings.Index(strings.ToLower(rest), " in "); idx >= 0 && idx < 12 {
+		rest = rest[idx:]
+	}
+	match := fieldPattern.FindStringSubmatch(rest)
+	if match == nil {
+		return nil
+	}
+	list := match[1]
+	if loc := requirementFieldEnd.FindStringIndex(list); loc != nil {
+		list = list[:loc[0]]
+	}
+
+	fields := make([]string, 0)
+	for _, field := range fieldSeparator.Split(list, -1) {
+		field = strings.Trim(field, " .:")
+		if field == "" || genericFields[strings.ToLower(field)] {
+			continue
+		}
+		fields = append(fields, limitWords(field, maxFieldWords))
+	}
+	return fields
+}
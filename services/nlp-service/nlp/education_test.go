@@ -0,0 +1,106 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractDegrees(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Degree
+	}{
+		{
+			name: "degree, school, year and GPA on one line",
+			text: "EDUCATION\nB.S. in Computer Science, Stanford University, 2018, GPA: 3.8/4.0",
+			want: []Degree{{Level: DegreeBachelor, Rank: 3, Degree: "B.S.", Field: "Computer Science",
+				Institution: "Stanford University", GraduationYear: 2018, GPA: 3.8, GPAScale: 4}},
+		},
+		{
+			name: "school on the line before, year and GPA after",
+			text: "EDUCATION\nUniversity of Toronto\nMaster of Science in Electrical Engineering\n2015 - 2017 | GPA 8.6",
+			want: []Degree{{Level: DegreeMaster, Rank: 4, Degree: "Master", Field: "Electrical Engineering",
+				Institution: "University of Toronto", GraduationYear: 2017, GPA: 8.6, GPAScale: 10}},
+		},
+		{
+			name: "several degrees",
+			text: "EDUCATION\nPhD in Physics, MIT, 2020\nBSc Mathematics, Imperial College London, 2014",
+			want: []Degree{
+				{Level: DegreeDoctorate, Rank: 5, Degree: "PhD", Field: "Physics", Institution: "MIT", GraduationYear: 2020},
+				{Level: DegreeBachelor, Rank: 3, Degree: "BSc", Institution: "Imperial College London", GraduationYear: 2014},
+			},
+		},
+		{
+			name: "abbreviation that is not a degree",
+			text: "EDUCATION\nCoursework in MS Office and Excel",
+			want: []Degree{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractDegrees(tt.text, DefaultLanguage); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractDegrees() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractEducationRequirement(t *testing.T) {
+	tests := []struct {
+		name string
+		jd   string
+		want *EducationRequirement
+	}{
+		{
+			name: "required degree with fields",
+			jd:   "Requirements:\nBachelor's degree in Computer Science, Engineering or a related field",
+			want: &EducationRequirement{Level: DegreeBachelor, Rank: 3, Fields: []string{"Computer Science", "Engineering"},
+				Required: true, Text: "Bachelor's degree in Computer Science, Engineering or a related field"},
+		},
+		{
+			name: "or equivalent experience",
+			jd:   "BS in Computer Science or equivalent experience",
+			want: &EducationRequirement{Level: DegreeBachelor, Rank: 3, Fields: []string{"Computer Science"},
+				Equivalent: true, Required: true, Text: "BS in Computer Science or equivalent experience"},
+		},
+		{
+			name: "lowest of several levels",
+			jd:   "BS/MS in Computer Science",
+			want: &EducationRequirement{Level: DegreeBachelor, Rank: 3, Fields: []string{"Computer Science"},
+				Required: true, Text: "BS/MS in Computer Science"},
+		},
+		{
+			name: "required line wins over a preferred one",
+			jd:   "Master's degree preferred\nBachelor's degree required",
+			want: &EducationRequirement{Level: DegreeBachelor, Rank: 3, Fields: []string{}, Required: true,
+				Text: "Bachelor's degree required"},
+		},
+		{
+			name: "only preferred",
+			jd:   "A PhD in Statistics is a plus",
+			want: &EducationRequirement{Level: DegreeDoctorate, Rank: 5, Fields: []string{"Statistics"},
+				Text: "A PhD in Statistics is a plus"},
+		},
+		{
+			name: "preference after the field",
+			jd:   "Master's degree in Mathematics or Physics preferred",
+			want: &EducationRequirement{Level: DegreeMaster, Rank: 4, Fields: []string{"Mathematics", "Physics"},
+				Text: "Master's degree in Mathematics or Physics preferred"},
+		},
+		{
+			name: "no degree",
+			jd:   "5+ years of Go experience",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractEducationRequirement(tt.jd); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractEducationRequirement() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
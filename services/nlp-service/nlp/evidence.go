@@ -0,0 +1,118 @@
+package nlp
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSnippetsPerSkill limits how many snippets are returned for one skill
+const maxSnippetsPerSkill = 5
+
+// EvidenceSnippet is a sentence from the resume where a skill appears
+type EvidenceSnippet struct {
+	Text    string `json:"text"`
+	Section string `json:"section"`
+	Start   int    `json:"start"` // UTF-16 offset of the skill in the parsed text, as JavaScript indexes strings
+	End     int    `json:"end"`
+}
+
+// SkillEvidence explains why a skill was matched
+type SkillEvidence struct {
+	Skill    string            `json:"skill"`
+	Sections []string          `json:"sections"`
+	Snippets []EvidenceSnippet `json:"snippets"`
+}
+
+// BuildSkillEvidence collects the sections and sentences behind each matched skill. Each
+// mention is placed in the section its position falls in, so a skill on the heading's own line
+// ("Skills: Python") still counts for that section.
+func BuildSkillEvidence(text string, matchedSkills []string, mentions []SkillMention, sections map[string]string, blocks []SectionBlock) []SkillEvidence {
+	spans := sectionSpans(text, sections)
+
+	evidence := make([]SkillEvidence, 0, len(matchedSkills))
+	for _, skill := range matchedSkills {
+		item := SkillEvidence{
+			Skill:    skill,
+			Sections: make([]string, 0),
+			Snippets: make([]EvidenceSnippet, 0),
+		}
+		seenSections := make(map[string]bool)
+
+		for _, mention := range mentions {
+			if mention.Skill != skill || mention.Classification != MentionPositive {
+				continue
+			}
+
+			section := findSection(text, mention.byteStart, blocks, spans)
+
+			if section != "" && !seenSections[section] {
+				seenSections[section] = true
+				item.Sections = append(item.Sections, section)
+			}
+
+			if len(item.Snippets) < maxSnippetsPerSkill {
+				item.Snippets = append(item.Snippets, EvidenceSnippet{
+					Text:    mention.Context,
+					Section: section,
+					Start:   mention.Start,
+					End:     mention.End,
+				})
+			}
+		}
+
+		evidence = append(evidence, item)
+	}
+
+	return evidence
+}
+
+// sectionSpan is the byte range of a section's content in the resume text
+type sectionSpan struct {
+	name       string
+	start, end int
+}
+
+// sectionSpans locates the content of each section found in running text, in name order so the
+// same text always maps to the same section. Line-based sections are placed by their blocks.
+func sectionSpans(text string, sections map[string]string) []sectionSpan {
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	spans := make([]sectionSpan, 0, len(names))
+	for _, name := range names {
+		content := sections[name]
+		if name == UnknownSection || name == OtherSection || strings.Contains(content, "\n") {
+			continue
+		}
+		if start := strings.Index(text, content); start >= 0 && content != "" {
+			spans = append(spans, sectionSpan{name: name, start: start, end: start + len(content)})
+		}
+	}
+	return spans
+}
+
+// findSection returns the section holding the byte offset: the block whose lines include it,
+// or, for headings found in running text, the section whose content covers it
+func findSection(text string, offset int, blocks []SectionBlock, spans []sectionSpan) string {
+	line := strings.Count(text[:offset], "\n") + 1
+	block := ""
+	for _, candidate := range blocks {
+		if line >= candidate.StartLine && line <= candidate.EndLine {
+			block = candidate.Name
+			break
+		}
+	}
+	if block != "" && block != UnknownSection {
+		return block
+	}
+
+	for _, span := range spans {
+		if offset >= span.start && offset < span.end {
+			return span.name
+		}
+	}
+	return block
+}
@@ -0,0 +1,68 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildSkillEvidence(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		skill        string
+		wantSections []string
+		wantOffsets  [][2]int // Start and end of each snippet
+	}{
+		{
+			name:         "heading lines",
+			text:         "Jane Doe\n\nEXPERIENCE\nBuilt data pipelines in Python\n\nSKILLS\nPython, SQL",
+			skill:        "python",
+			wantSections: []string{"experience", "skills"},
+			wantOffsets:  [][2]int{{45, 51}, {60, 66}},
+		},
+		{
+			name:         "heading on the same line as the content",
+			text:         "Summary: Backend engineer building services. Skills: Python and SQL for reporting.",
+			skill:        "python",
+			wantSections: []string{"skills"},
+			wantOffsets:  [][2]int{{53, 59}},
+		},
+		{
+			name:         "wrapped sentence",
+			text:         "EXPERIENCE\nBuilt pipelines that moved billing data\nto Python services",
+			skill:        "python",
+			wantSections: []string{"experience"},
+			wantOffsets:  [][2]int{{54, 60}},
+		},
+		{
+			// "é" is one UTF-16 unit and "🚀" two, as JavaScript counts them
+			name:         "offsets in UTF-16 units",
+			text:         "EXPERIENCE\n🚀 Café ordering app in Python",
+			skill:        "python",
+			wantSections: []string{"experience"},
+			wantOffsets:  [][2]int{{35, 41}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections := ClassifySectionsLanguage(tt.text, DefaultLanguage)
+			blocks := ExtractSectionBlocks(tt.text, DefaultLanguage, nil)
+			evidence := BuildSkillEvidence(tt.text, []string{tt.skill}, ExtractSkillMentions(tt.text), sections, blocks)
+			if len(evidence) != 1 {
+				t.Fatalf("got evidence for %d skills, want 1", len(evidence))
+			}
+
+			if !reflect.DeepEqual(evidence[0].Sections, tt.wantSections) {
+				t.Errorf("sections = %q, want %q", evidence[0].Sections, tt.wantSections)
+			}
+			offsets := make([][2]int, 0, len(evidence[0].Snippets))
+			for _, snippet := range evidence[0].Snippets {
+				offsets = append(offsets, [2]int{snippet.Start, snippet.End})
+			}
+			if !reflect.DeepEqual(offsets, tt.wantOffsets) {
+				t.Errorf("offsets = %v, want %v", offsets, tt.wantOffsets)
+			}
+		})
+	}
+}
@@ -1,24 +1,49 @@
 package nlp
 
 import (
+	"fmt"
+
 	"github.com/gofiber/fiber/v2"
 )
 
 // AnalyzeRequest represents the analysis request
 type AnalyzeRequest struct {
-	ResumeText     string `json:"resumeText"`
-	JobDescription string `json:"jobDescription"`
+	ResumeText     string   `json:"resumeText"`
+	JobDescription string   `json:"jobDescription"`
+	HeadingHints   []string `json:"headingHints"` // Resume lines set in bold or larger fonts
 }
 
 // AnalyzeResponse represents the analysis result
 type AnalyzeResponse struct {
-	Keywords        []string          `json:"keywords"`
-	Skills          []string          `json:"skills"`
-	Sections        map[string]string `json:"sections"`
-	SimilarityScore float64           `json:"similarityScore"`
-	MatchedSkills   []string          `json:"matchedSkills"`
-	MissingSkills   []string          `json:"missingSkills"`
-	Error           string            `json:"error,omitempty"`
+	Keywords              []string              `json:"keywords"`
+	Skills                []string              `json:"skills"`
+	Sections              map[string]string     `json:"sections"`
+	SectionConfidence     map[string]float64    `json:"sectionConfidence"`
+	SectionBlocks         []SectionBlock        `json:"sectionBlocks"`
+	SimilarityScore       float64               `json:"similarityScore"`
+	MatchedSkills         []string              `json:"matchedSkills"`
+	MissingSkills         []string              `json:"missingSkills"`
+	JDSkillTerms          map[string]string     `json:"jdSkillTerms"` // Each JD skill as the JD writes it
+	Certifications        []string              `json:"certifications"`
+	CertificationTerms    map[string][]string   `json:"certificationTerms"` // How the resume writes each certification
+	MatchedCertifications []string              `json:"matchedCertifications"`
+	MissingCertifications []string              `json:"missingCertifications"`
+	Degrees               []Degree              `json:"degrees"`
+	EducationRequirement  *EducationRequirement `json:"educationRequirement"` // Nil when the JD names no degree
+	JobRequirements       JobRequirements       `json:"jobRequirements"`
+	WorkAuthorization     string                `json:"workAuthorization"` // authorized, sponsorship or unknown
+	SkillEvidence         []SkillEvidence       `json:"skillEvidence"`
+	SkillMentions         []SkillMention        `json:"skillMentions"`
+	Positions             []Position            `json:"positions"`
+	SkillProfiles         []SkillProfile        `json:"skillProfiles"`
+	TitleAlignment        TitleAlignment        `json:"titleAlignment"`
+	ExperienceYears       int                   `json:"experienceYears"`
+	Readability           Readability           `json:"readability"`
+	RequiresCurrentUse    bool                  `json:"requiresCurrentUse"`
+	Stuffing              StuffingReport        `json:"stuffing"`
+	Language              LanguageReport        `json:"language"`
+	Warnings              []string              `json:"warnings"`
+	Error                 string                `json:"error,omitempty"`
 }
 
 // HandleAnalyze processes resume and JD analysis
@@ -36,11 +61,21 @@ func HandleAnalyze(c *fiber.Ctx) error {
 		})
 	}
 
+	// Detect resume and JD languages to pick stop words and stemmers
+	language := CompareLanguages(req.ResumeText, req.JobDescription)
+	warnings := make([]string, 0)
+	if language.Mismatch {
+		warnings = append(warnings, fmt.Sprintf(
+			"Your resume appears to be in %s but the job description is in %s. Many ATS systems auto-reject resumes that are not in the posting's language.",
+			language.Resume.Name, language.JobDescription.Name))
+	}
+
 	// Extract keywords from resume
-	resumeKeywords := ExtractKeywords(req.ResumeText)
+	resumeKeywords := ExtractKeywordsLanguage(req.ResumeText, language.Resume.Code)
 
-	// Extract skills from resume
-	resumeSkills := ExtractSkills(req.ResumeText)
+	// Extract skill mentions from resume; only positive mentions count as skills
+	skillMentions := ExtractSkillMentions(req.ResumeText)
+	resumeSkills := PositiveSkills(skillMentions)
 
 	// Extract required skills from JD
 	jdSkills := ExtractSkills(req.JobDescription)
@@ -48,20 +83,102 @@ func HandleAnalyze(c *fiber.Ctx) error {
 	// Find matched and missing skills
 	matchedSkills, missingSkills := CompareSkills(resumeSkills, jdSkills)
 
+	// Match certifications; the JD counts every certification it names, the resume only earned ones
+	resumeCertifications := ExtractCertifications(req.ResumeText, false)
+	jdCertifications := ExtractCertifications(req.JobDescription, true)
+	matchedCertifications, missingCertifications := CompareCertifications(resumeCertifications, jdCertifications)
+
+	// Extract degrees and the JD's degree requirement
+	degrees := ExtractDegrees(req.ResumeText, language.Resume.Code)
+	educationRequirement := ExtractEducationRequirement(req.JobDescription)
+
+	// Find the JD's hard requirements and the resume's work authorization, for knockout screening
+	jobRequirements := ExtractJobRequirements(req.JobDescription)
+	workAuthorization := DetectWorkAuthorization(req.ResumeText)
+
 	// Classify resume sections
-	sections := ClassifySections(req.ResumeText)
+	sections, sectionConfidence := ClassifySectionsWithConfidence(req.ResumeText, language.Resume.Code, req.HeadingHints)
+	sectionBlocks := ExtractSectionBlocks(req.ResumeText, language.Resume.Code, req.HeadingHints)
+
+	// Collect the sections and sentences behind each matched skill
+	skillEvidence := BuildSkillEvidence(req.ResumeText, matchedSkills, skillMentions, sections, sectionBlocks)
+
+	// Estimate recency, frequency and proficiency per skill from the experience positions
+	positions := ExtractPositionsLanguage(req.ResumeText, language.Resume.Code)
+	skillProfiles := BuildSkillProfiles(skillMentions, positions)
+
+	// Compare the role being hired for with the candidate's title history
+	titleAlignment := AlignTitles(req.JobDescription, positions)
+
+	// Measure length and readability of the resume
+	readability := MeasureReadability(req.ResumeText)
 
 	// Calculate TF-IDF similarity
-	similarity := CalculateSimilarity(req.ResumeText, req.JobDescription)
+	similarity := CalculateLanguageSimilarity(req.ResumeText, language.Resume.Code, req.JobDescription, language.JobDescription.Code)
+
+	// Detect keyword stuffing and text copied from the JD
+	stuffing := DetectKeywordStuffing(req.ResumeText, req.JobDescription)
 
 	response := AnalyzeResponse{
-		Keywords:        resumeKeywords,
-		Skills:          resumeSkills,
-		Sections:        sections,
-		SimilarityScore: similarity,
-		MatchedSkills:   matchedSkills,
-		MissingSkills:   missingSkills,
+		Keywords:              resumeKeywords,
+		Skills:                resumeSkills,
+		Sections:              sections,
+		SectionConfidence:     sectionConfidence,
+		SectionBlocks:         sectionBlocks,
+		SimilarityScore:       similarity,
+		MatchedSkills:         matchedSkills,
+		MissingSkills:         missingSkills,
+		JDSkillTerms:          SkillTerms(req.JobDescription, jdSkills),
+		Certifications:        resumeCertifications,
+		CertificationTerms:    CertificationTerms(req.ResumeText),
+		MatchedCertifications: matchedCertifications,
+		MissingCertifications: missingCertifications,
+		Degrees:               degrees,
+		EducationRequirement:  educationRequirement,
+		JobRequirements:       jobRequirements,
+		WorkAuthorization:     workAuthorization,
+		SkillEvidence:         skillEvidence,
+		SkillMentions:         skillMentions,
+		Positions:             positions,
+		SkillProfiles:         skillProfiles,
+		TitleAlignment:        titleAlignment,
+		ExperienceYears:       TotalExperienceYears(positions),
+		Readability:           readability,
+		RequiresCurrentUse:    RequiresCurrentUse(req.JobDescription),
+		Stuffing:              stuffing,
+		Language:              language,
+		Warnings:              warnings,
 	}
 
 	return c.JSON(response)
 }
+
+// CertificationsRequest lists certification names as someone typed them
+type CertificationsRequest struct {
+	Names []string `json:"names"`
+}
+
+// CertificationsResponse gives the catalog name for each requested name, in request order.
+// A name the catalog doesn't know is returned as an empty string.
+type CertificationsResponse struct {
+	Certifications []string `json:"certifications"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// HandleCertifications maps certification names and aliases, such as "AWS SAA", to the
+// catalog names the analysis reports
+func HandleCertifications(c *fiber.Ctx) error {
+	var req CertificationsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(CertificationsResponse{
+			Error: "Invalid request body",
+		})
+	}
+
+	certifications := make([]string, 0, len(req.Names))
+	for _, name := range req.Names {
+		certifications = append(certifications, CanonicalCertification(name))
+	}
+
+	return c.JSON(CertificationsResponse{Certifications: certifications})
+}
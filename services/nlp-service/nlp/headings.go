@@ -0,0 +1,16 @@
+package nlp
+
+import sharedlang "github.com/kedar/ats-checker/shared/lang"
+
+// HeadingThreshold is the score a line needs to count as a section heading
+const HeadingThreshold = sharedlang.HeadingThreshold
+
+// HeadingContext describes where a candidate heading line sits in the document
+type HeadingContext = sharedlang.HeadingContext
+
+// ScoreHeading rates how likely a line matching a section heading is to really be a heading,
+// so body lines like "Improved user experience by 30%" do not start a new section. The
+// parser scores headings with the same shared implementation.
+func ScoreHeading(line string, match HeadingMatch, ctx HeadingContext) float64 {
+	return sharedlang.ScoreHeading(line, match.Confidence, ctx)
+}
@@ -1,7 +1,10 @@
 package nlp
 
 import (
+	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // TechnicalSkills database of known technical skills
@@ -48,27 +51,73 @@ var SoftSkills = []string{
 
 // ExtractSkills extracts skills mentioned in text
 func ExtractSkills(text string) []string {
-	textLower := strings.ToLower(text)
 	foundSkills := make([]string, 0)
 	seen := make(map[string]bool)
 
-	// Check for technical skills
-	for _, skill := range TechnicalSkills {
-		if strings.Contains(textLower, skill) && !seen[skill] {
+	for _, skill := range allSkills() {
+		if !seen[skill] && len(findSkillOccurrences(text, skill)) > 0 {
 			seen[skill] = true
 			foundSkills = append(foundSkills, skill)
 		}
 	}
 
-	// Check for soft skills
-	for _, skill := range SoftSkills {
-		if strings.Contains(textLower, skill) && !seen[skill] {
-			seen[skill] = true
-			foundSkills = append(foundSkills, skill)
+	return foundSkills
+}
+
+// SkillTerms returns each skill as text first writes it, e.g. "Kubernetes" for "kubernetes".
+// Skills text doesn't mention are left out.
+func SkillTerms(text string, skills []string) map[string]string {
+	terms := make(map[string]string, len(skills))
+	for _, skill := range skills {
+		if occurrences := findSkillOccurrences(text, skill); len(occurrences) > 0 {
+			terms[skill] = text[occurrences[0][0]:occurrences[0][1]]
 		}
 	}
+	return terms
+}
 
-	return foundSkills
+// allSkills returns technical skills followed by soft skills
+func allSkills() []string {
+	skills := make([]string, 0, len(TechnicalSkills)+len(SoftSkills))
+	skills = append(skills, TechnicalSkills...)
+	return append(skills, SoftSkills...)
+}
+
+// skillPatterns holds the precompiled case-insensitive pattern for each known skill
+var skillPatterns = compileSkillPatterns(allSkills())
+
+func compileSkillPatterns(skills []string) map[string]*regexp.Regexp {
+	patterns := make(map[string]*regexp.Regexp, len(skills))
+	for _, skill := range skills {
+		patterns[skill] = regexp.MustCompile(`(?i)` + regexp.QuoteMeta(skill))
+	}
+	return patterns
+}
+
+// findSkillOccurrences returns byte offsets of whole-word occurrences of skill in text
+func findSkillOccurrences(text, skill string) [][]int {
+	pattern, ok := skillPatterns[skill]
+	if !ok {
+		pattern = regexp.MustCompile(`(?i)` + regexp.QuoteMeta(skill))
+	}
+
+	occurrences := make([][]int, 0)
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		// Reject matches inside a larger word, e.g. "go" in "good" or "ml" in "html"
+		before, _ := utf8.DecodeLastRuneInString(text[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(text[loc[1]:])
+		if isSkillRune(before) || isSkillRune(after) {
+			continue
+		}
+		occurrences = append(occurrences, loc)
+	}
+
+	return occurrences
+}
+
+// isSkillRune reports whether r can be part of a skill name
+func isSkillRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#'
 }
 
 // CompareSkills finds matched and missing skills
@@ -0,0 +1,23 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkillTerms(t *testing.T) {
+	jd := "We run Kubernetes on AWS. Experience with JavaScript and kubernetes operators is a plus."
+	got := SkillTerms(jd, ExtractSkills(jd))
+	want := map[string]string{"kubernetes": "Kubernetes", "aws": "AWS", "javascript": "JavaScript"}
+	for skill, term := range want {
+		if got[skill] != term {
+			t.Errorf("SkillTerms[%q] = %q, want %q", skill, got[skill], term)
+		}
+	}
+	if _, ok := got["go"]; ok {
+		t.Errorf("SkillTerms = %v, includes a skill the JD doesn't mention", got)
+	}
+	if !reflect.DeepEqual(SkillTerms("", []string{"go"}), map[string]string{}) {
+		t.Errorf("SkillTerms of empty text is not empty")
+	}
+}
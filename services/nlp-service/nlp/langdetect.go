@@ -0,0 +1,52 @@
+package nlp
+
+import (
+	"sort"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+)
+
+// LanguageGuess is the detected language of a text
+type LanguageGuess struct {
+	Code       string  `json:"code"`
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"` // 0-1, margin over the runner-up
+}
+
+// LanguageReport describes the languages of the resume and JD
+type LanguageReport struct {
+	Resume         LanguageGuess `json:"resume"`
+	JobDescription LanguageGuess `json:"jobDescription"`
+	Mismatch       bool          `json:"mismatch"`
+}
+
+// DetectLanguage identifies the language of text offline using character n-gram profiles and
+// stop words. The parser detects the resume language with the same shared implementation.
+func DetectLanguage(text string) LanguageGuess {
+	languagesMu.RLock()
+	codes := make([]string, 0, len(languages))
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	profiles := make([]sharedlang.Profile, 0, len(codes))
+	for _, code := range codes {
+		profiles = append(profiles, languages[code].detection)
+	}
+	languagesMu.RUnlock()
+
+	code, confidence := sharedlang.Detect(text, profiles, DefaultLanguage)
+	lang := GetLanguage(code)
+	return LanguageGuess{Code: lang.Code, Name: lang.Name, Confidence: confidence}
+}
+
+// CompareLanguages detects both languages and reports whether they differ
+func CompareLanguages(resumeText, jdText string) LanguageReport {
+	resume := DetectLanguage(resumeText)
+	jd := DetectLanguage(jdText)
+	return LanguageReport{
+		Resume:         resume,
+		JobDescription: jd,
+		Mismatch:       resume.Code != jd.Code,
+	}
+}
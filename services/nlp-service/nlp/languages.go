@@ -0,0 +1,182 @@
+package nlp
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+)
+
+// DefaultLanguage is used when no language is given or the language is unknown
+const DefaultLanguage = "en"
+
+// minStemRunes is the shortest stem the stemmer will produce
+const minStemRunes = 3
+
+// Language holds the resources the analysis pipeline needs for one language
+type Language struct {
+	Code         string   `json:"code"`
+	Name         string   `json:"name"`
+	StopWords    []string `json:"stopWords"`
+	StemSuffixes []string `json:"stemSuffixes"` // Suffixes stripped by the light stemmer
+	Sample       string   `json:"sample"`       // Representative text used to build the n-gram profile
+	// SectionHeadings maps a section name to the headings that introduce it
+	SectionHeadings map[string][]string `json:"sectionHeadings"`
+
+	detection       sharedlang.Profile
+	sectionPatterns []SectionPattern
+}
+
+var (
+	languagesMu sync.RWMutex
+	languages   = loadLanguages()
+)
+
+// loadLanguages reads the built-in language files shared with resume-parser, then any files in LANGUAGE_DIR
+func loadLanguages() map[string]*Language {
+	loaded := make(map[string]*Language)
+	for code, file := range sharedlang.LoadFiles(os.Getenv("LANGUAGE_DIR")) {
+		loaded[code] = prepareLanguage(Language{
+			Code:            file.Code,
+			Name:            file.Name,
+			StopWords:       file.StopWords,
+			StemSuffixes:    file.StemSuffixes,
+			Sample:          file.Sample,
+			SectionHeadings: file.SectionHeadings,
+		})
+	}
+
+	linkSectionPatterns(loaded)
+	return loaded
+}
+
+// prepareLanguage normalizes a language definition and builds its lookup sets
+func prepareLanguage(lang Language) *Language {
+	lang.Code = strings.ToLower(lang.Code)
+
+	// Try the longest suffix first
+	suffixes := make([]string, 0, len(lang.StemSuffixes))
+	for _, suffix := range lang.StemSuffixes {
+		suffixes = append(suffixes, strings.ToLower(suffix))
+	}
+	sort.SliceStable(suffixes, func(i, j int) bool {
+		return utf8.RuneCountInString(suffixes[i]) > utf8.RuneCountInString(suffixes[j])
+	})
+	lang.StemSuffixes = suffixes
+
+	lang.detection = sharedlang.NewProfile(sharedlang.File{
+		Code:      lang.Code,
+		StopWords: lang.StopWords,
+		Sample:    lang.Sample,
+	})
+
+	return &lang
+}
+
+// Tokenize breaks text into tokens using the language's stop words
+func (l *Language) Tokenize(text string) []string {
+	return TokenizeWithStopWords(text, l.detection.StopWords)
+}
+
+// Stem strips the longest known suffix from a token, keeping at least minStemRunes runes.
+// Tokens with technical punctuation or digits ("c++", "node.js", "s3") are left alone.
+func (l *Language) Stem(token string) string {
+	for _, r := range token {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+			return token
+		}
+	}
+
+	length := utf8.RuneCountInString(token)
+	for _, suffix := range l.StemSuffixes {
+		if strings.HasSuffix(token, suffix) && length-utf8.RuneCountInString(suffix) >= minStemRunes {
+			return strings.TrimSuffix(token, suffix)
+		}
+	}
+	return token
+}
+
+// Terms tokenizes and stems text for similarity comparisons
+func (l *Language) Terms(text string) []string {
+	tokens := l.Tokenize(text)
+	for i, token := range tokens {
+		tokens[i] = l.Stem(token)
+	}
+	return tokens
+}
+
+// linkSectionPatterns compiles each language's heading patterns, set on the Language values
+// in loaded. English headings are included for every language since they are common in
+// non-English resumes; the parser resolves headings the same way.
+func linkSectionPatterns(loaded map[string]*Language) {
+	var english map[string][]string
+	if lang, ok := loaded[DefaultLanguage]; ok {
+		english = lang.SectionHeadings
+	}
+
+	for code, lang := range loaded {
+		if code == DefaultLanguage {
+			lang.sectionPatterns = sharedlang.SectionPatterns(lang.SectionHeadings, nil)
+		} else {
+			lang.sectionPatterns = sharedlang.SectionPatterns(lang.SectionHeadings, english)
+		}
+	}
+}
+
+// SectionPatterns returns the heading patterns for the language, including English headings,
+// in priority order
+func (l *Language) SectionPatterns() []SectionPattern {
+	return l.sectionPatterns
+}
+
+// RegisterLanguage adds or replaces the resources for a language. Languages already handed
+// out by GetLanguage are never changed: the registry is rebuilt from copies and swapped in.
+func RegisterLanguage(lang Language) {
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+
+	updated := make(map[string]*Language, len(languages)+1)
+	for code, existing := range languages {
+		copied := *existing
+		updated[code] = &copied
+	}
+	prepared := prepareLanguage(lang)
+	updated[prepared.Code] = prepared
+	linkSectionPatterns(updated)
+
+	languages = updated
+}
+
+// GetLanguage returns the resources for a language code, falling back to DefaultLanguage
+func GetLanguage(code string) *Language {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	if lang, ok := languages[strings.ToLower(code)]; ok {
+		return lang
+	}
+	return languages[DefaultLanguage]
+}
+
+// SupportedLanguages returns the codes of all loaded languages in sorted order
+func SupportedLanguages() []string {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	codes := make([]string, 0, len(languages))
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// StopWordsFor returns the stop word set for a language code
+func StopWordsFor(code string) map[string]bool {
+	if lang := GetLanguage(code); lang != nil {
+		return lang.detection.StopWords
+	}
+	return map[string]bool{}
+}
@@ -0,0 +1,35 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegisterLanguageKeepsHandedOutLanguages(t *testing.T) {
+	original := languages
+	t.Cleanup(func() {
+		languagesMu.Lock()
+		languages = original
+		languagesMu.Unlock()
+	})
+
+	spanish := GetLanguage("es")
+	before := spanish.SectionPatterns()
+
+	// Replacing English relinks every language's headings
+	RegisterLanguage(Language{
+		Code:            "EN",
+		Name:            "English (custom)",
+		SectionHeadings: map[string][]string{"skills": {"toolbox"}},
+	})
+
+	if got := GetLanguage("en").Name; got != "English (custom)" {
+		t.Errorf("en is %q, want the registered language", got)
+	}
+	if !reflect.DeepEqual(spanish.SectionPatterns(), before) {
+		t.Errorf("a language returned before RegisterLanguage was changed")
+	}
+	if match, _ := ClassifyHeading("Toolbox", GetLanguage("es").SectionPatterns()); match.Section != "skills" {
+		t.Errorf("Spanish headings do not include the registered English ones")
+	}
+}
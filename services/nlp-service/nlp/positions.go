@@ -0,0 +1,238 @@
+package nlp
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+)
+
+// Proficiency tiers
+const (
+	ProficiencyExpert       = "expert"
+	ProficiencyAdvanced     = "advanced"
+	ProficiencyIntermediate = "intermediate"
+	ProficiencyBeginner     = "beginner"
+)
+
+const (
+	// staleYears is how long ago a skill can be used before its proficiency is downgraded
+	staleYears = 5
+	// maxTitleWords is the longest line still treated as a position title
+	maxTitleWords = 10
+)
+
+// monthPattern matches month names and abbreviations in the supported languages
+const monthPattern = `(?:jan|feb|fev|fév|mar|mär|apr|avr|abr|may|mai|mayo|jun|juin|jul|juil|aug|ago|août|sep|set|oct|okt|out|nov|dec|dez|dic|déc|ene)\p{L}*\.?`
+
+// ongoingPattern matches "present" and its translations
+const ongoingPattern = `present|current|now|today|ongoing|presente|actual|actualidad|heute|aktuell|jetzt|aujourd'hui|présent|atual|hoje`
+
+// DateRangePattern matches employment date ranges such as "Jan 2019 - Present" or "03/2018 – 2021"
+var DateRangePattern = regexp.MustCompile(`(?i)(?:` + monthPattern + `\s+|\d{1,2}/)?((?:19|20)\d{2})\s*(?:-|–|—|to|until|bis|hasta|à|a|até)\s*(?:` +
+	monthPattern + `\s+|\d{1,2}/)?((?:19|20)\d{2}|` + ongoingPattern + `)`)
+
+// CurrentUsePattern matches JD language implying current hands-on use of the required skills
+var CurrentUsePattern = regexp.MustCompile(`(?i)\b(hands[- ]on|currently|day[- ]to[- ]day|daily|recent experience|` +
+	`actively|production experience|in the (?:last|past) \d+ years)\b`)
+
+// Position represents one role in the experience section
+type Position struct {
+	Title     string   `json:"title"`
+	StartYear int      `json:"startYear"` // 0 when the position has no dates
+	EndYear   int      `json:"endYear"`   // Current year for ongoing positions
+	Current   bool     `json:"current"`
+	Lines     []string `json:"lines"`
+}
+
+// SkillProfile estimates how recently, how often and how well a skill has been used
+type SkillProfile struct {
+	Skill       string `json:"skill"`
+	Frequency   int    `json:"frequency"` // Positive mentions across the whole resume
+	Positions   int    `json:"positions"` // Positions whose text mentions the skill
+	LastUsed    int    `json:"lastUsed"`  // Year, 0 when the skill is not tied to a dated position
+	YearsUsed   int    `json:"yearsUsed"`
+	Current     bool   `json:"current"`
+	Proficiency string `json:"proficiency"`
+}
+
+// ExtractPositions splits the experience section into positions using their date ranges
+func ExtractPositions(text string) []Position {
+	return ExtractPositionsLanguage(text, DefaultLanguage)
+}
+
+// ExtractPositionsLanguage extracts positions from a resume whose headings are in the given language
+func ExtractPositionsLanguage(text, lang string) []Position {
+	positions := make([]Position, 0)
+	var current *Position
+
+	for _, line := range sectionLines(text, "experience", lang) {
+		match := DateRangePattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			if current == nil {
+				current = &Position{}
+			}
+			current.Lines = append(current.Lines, line)
+			continue
+		}
+
+		// A dated line starts a new position
+		start, _ := strconv.Atoi(line[match[2]:match[3]])
+		end, ongoing := parseEndYear(line[match[4]:match[5]])
+		next := Position{StartYear: start, EndYear: end, Current: ongoing}
+
+		// The title is on the dated line, the line just before it, or both
+		next.Title = strings.Trim(line[:match[0]]+" "+line[match[1]:], " |,-–—()")
+		if current != nil && len(current.Lines) > 0 {
+			previous := current.Lines[len(current.Lines)-1]
+			if looksLikeTitle(previous) {
+				current.Lines = current.Lines[:len(current.Lines)-1]
+				next.Title = strings.TrimSpace(strings.Trim(previous, " |,") + " " + next.Title)
+			}
+		}
+
+		if current != nil && (current.Title != "" || len(current.Lines) > 0) {
+			positions = append(positions, *current)
+		}
+		current = &next
+	}
+
+	if current != nil && (current.Title != "" || len(current.Lines) > 0) {
+		positions = append(positions, *current)
+	}
+
+	return positions
+}
+
+// parseEndYear converts the end of a date range to a year and reports whether it is ongoing
+func parseEndYear(value string) (int, bool) {
+	if year, err := strconv.Atoi(value); err == nil {
+		return year, false
+	}
+	return time.Now().Year(), true
+}
+
+// looksLikeTitle reports whether a line reads like a job title or company rather than a bullet
+func looksLikeTitle(line string) bool {
+	return !sharedlang.IsBullet(line) && !strings.HasSuffix(line, ".") && len(strings.Fields(line)) <= maxTitleWords
+}
+
+// BuildSkillProfiles estimates recency, frequency and proficiency for every skill in the resume
+func BuildSkillProfiles(mentions []SkillMention, positions []Position) []SkillProfile {
+	frequency := make(map[string]int)
+	learning := make(map[string]bool)
+	for _, mention := range mentions {
+		switch mention.Classification {
+		case MentionPositive:
+			frequency[mention.Skill]++
+		case MentionLearning:
+			learning[mention.Skill] = true
+		}
+	}
+
+	profiles := make([]SkillProfile, 0)
+	for _, skill := range allSkills() {
+		if frequency[skill] == 0 && !learning[skill] {
+			continue
+		}
+
+		profile := SkillProfile{Skill: skill, Frequency: frequency[skill]}
+		years := make(map[int]bool)
+
+		for _, position := range positions {
+			if !positionUsesSkill(position, skill) {
+				continue
+			}
+			profile.Positions++
+			profile.Current = profile.Current || position.Current
+
+			if position.StartYear == 0 {
+				continue
+			}
+			if position.EndYear > profile.LastUsed {
+				profile.LastUsed = position.EndYear
+			}
+			// Count calendar years once even when positions overlap
+			for year := position.StartYear; year < position.EndYear || year == position.StartYear; year++ {
+				years[year] = true
+			}
+		}
+
+		profile.YearsUsed = len(years)
+		profile.Proficiency = inferProficiency(profile)
+		profiles = append(profiles, profile)
+	}
+
+	return profiles
+}
+
+// positionUsesSkill reports whether a position's text mentions the skill positively
+func positionUsesSkill(position Position, skill string) bool {
+	text := strings.Join(append([]string{position.Title}, position.Lines...), "\n")
+	for _, loc := range findSkillOccurrences(text, skill) {
+		if classifyMention(text, loc[0]) == MentionPositive {
+			return true
+		}
+	}
+	return false
+}
+
+// inferProficiency maps usage signals to a proficiency tier
+func inferProficiency(profile SkillProfile) string {
+	if profile.Frequency == 0 {
+		// Only mentioned as something being learned
+		return ProficiencyBeginner
+	}
+
+	tiers := []string{ProficiencyBeginner, ProficiencyIntermediate, ProficiencyAdvanced, ProficiencyExpert}
+	tier := 0
+	switch {
+	case profile.YearsUsed >= 5 && profile.Positions >= 2:
+		tier = 3
+	case profile.YearsUsed >= 3 || profile.Positions >= 2:
+		tier = 2
+	case profile.YearsUsed >= 1 || profile.Frequency >= 2:
+		tier = 1
+	}
+
+	// Skills not used for years have likely gone rusty
+	if profile.LastUsed > 0 && !profile.Current && time.Now().Year()-profile.LastUsed > staleYears && tier > 0 {
+		tier--
+	}
+
+	return tiers[tier]
+}
+
+// RequiresCurrentUse reports whether the JD asks for current, hands-on use of its skills
+func RequiresCurrentUse(jdText string) bool {
+	return CurrentUsePattern.MatchString(jdText)
+}
+
+// TotalExperienceYears counts the calendar years covered by dated positions, counting
+// overlapping positions once
+func TotalExperienceYears(positions []Position) int {
+	type span struct{ start, end int }
+	var spans []span
+	for _, position := range positions {
+		if position.StartYear > 0 && position.EndYear >= position.StartYear {
+			spans = append(spans, span{position.StartYear, position.EndYear})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	total := 0
+	covered := 0 // Last year already counted
+	for _, s := range spans {
+		if s.start < covered {
+			s.start = covered
+		}
+		if s.end > s.start {
+			total += s.end - s.start
+			covered = s.end
+		}
+	}
+	return total
+}
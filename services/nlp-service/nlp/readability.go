@@ -0,0 +1,134 @@
+package nlp
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+)
+
+const (
+	// wordsPerPage and linesPerPage are typical for a resume set in a 10-11pt font
+	wordsPerPage = 500
+	linesPerPage = 50
+	// minSentenceWords leaves headings, names and contact lines out of sentence statistics
+	minSentenceWords = 3
+)
+
+// sentenceEndPattern matches the end of a sentence
+var sentenceEndPattern = regexp.MustCompile(`[.!?]+(?:\s+|$)`)
+
+// vowelGroupPattern matches the vowel groups used to estimate syllables
+var vowelGroupPattern = regexp.MustCompile(`[aeiouy]+`)
+
+// Readability describes how long a resume is and how easy it is to read
+type Readability struct {
+	WordCount          int     `json:"wordCount"`
+	SentenceCount      int     `json:"sentenceCount"` // Sentences and bullets of at least three words
+	BulletCount        int     `json:"bulletCount"`
+	LineCount          int     `json:"lineCount"` // Non-empty lines
+	AvgSentenceLength  float64 `json:"avgSentenceLength"`
+	AvgBulletLength    float64 `json:"avgBulletLength"`
+	FleschReadingEase  float64 `json:"fleschReadingEase"`  // 0-100, higher is easier
+	FleschKincaidGrade float64 `json:"fleschKincaidGrade"` // US school grade level
+	EstimatedPages     float64 `json:"estimatedPages"`
+	BulletDensity      float64 `json:"bulletDensity"` // Share of non-empty lines that are bullets
+}
+
+// MeasureReadability computes length and readability statistics for resume text.
+// Flesch scores use English syllable rules, so they are approximate for other languages.
+func MeasureReadability(text string) Readability {
+	var readability Readability
+	var units []string // Bullets and paragraphs, with wrapped lines joined back together
+	bulletUnit := make(map[int]bool)
+
+	continues := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continues = false
+			continue
+		}
+		readability.LineCount++
+
+		if sharedlang.IsBullet(line) {
+			readability.BulletCount++
+			bulletUnit[len(units)] = true
+			units = append(units, strings.TrimLeft(line, strings.Join(sharedlang.BulletPrefixes, "")+" "))
+			continues = true
+			continue
+		}
+		// A wrapped line continues in lower case
+		if continues && unicode.IsLower([]rune(line)[0]) {
+			units[len(units)-1] += " " + line
+			continue
+		}
+		units = append(units, line)
+		continues = !strings.HasSuffix(line, ".")
+	}
+
+	var sentenceWords, bulletWords, syllables int
+	for i, unit := range units {
+		words := splitWords(unit)
+		readability.WordCount += len(words)
+		if bulletUnit[i] {
+			bulletWords += len(words)
+		}
+
+		for _, sentence := range sentenceEndPattern.Split(unit, -1) {
+			words := splitWords(sentence)
+			if len(words) < minSentenceWords {
+				continue
+			}
+			readability.SentenceCount++
+			sentenceWords += len(words)
+			for _, word := range words {
+				syllables += countSyllables(word)
+			}
+		}
+	}
+
+	if readability.SentenceCount > 0 {
+		wordsPerSentence := float64(sentenceWords) / float64(readability.SentenceCount)
+		syllablesPerWord := float64(syllables) / float64(sentenceWords)
+		readability.AvgSentenceLength = roundTenth(wordsPerSentence)
+		readability.FleschReadingEase = roundTenth(math.Max(0, math.Min(100, 206.835-1.015*wordsPerSentence-84.6*syllablesPerWord)))
+		readability.FleschKincaidGrade = roundTenth(math.Max(0, 0.39*wordsPerSentence+11.8*syllablesPerWord-15.59))
+	}
+	if readability.BulletCount > 0 {
+		readability.AvgBulletLength = roundTenth(float64(bulletWords) / float64(readability.BulletCount))
+	}
+	if readability.LineCount > 0 {
+		readability.BulletDensity = math.Round(float64(readability.BulletCount)/float64(readability.LineCount)*100) / 100
+	}
+	readability.EstimatedPages = roundTenth(math.Max(
+		float64(readability.WordCount)/wordsPerPage,
+		float64(readability.LineCount)/linesPerPage,
+	))
+
+	return readability
+}
+
+// countSyllables estimates the syllables in an English word from its vowel groups
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	if !strings.ContainsFunc(word, unicode.IsLetter) {
+		return 1 // Figures such as "40%" read as one unit
+	}
+	count := len(vowelGroupPattern.FindAllString(word, -1))
+	// A final silent "e" is not a syllable, but "-le" after a consonant is ("table")
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+// roundTenth rounds a statistic to one decimal place
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
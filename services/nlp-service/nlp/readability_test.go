@@ -0,0 +1,93 @@
+package nlp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountSyllables(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"cat", 1},
+		{"the", 1},
+		{"make", 1},   // Silent final "e"
+		{"table", 2},  // "-le" after a consonant
+		{"rhythm", 1}, // "y" as a vowel
+		{"engineering", 4},
+		{"services", 3},
+		{"40%", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := countSyllables(tt.word); got != tt.want {
+				t.Errorf("countSyllables(%q) = %d, want %d", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeasureReadability(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Readability
+	}{
+		{
+			// Three sentences of 16 words and 18 syllables; "Jane Doe" and the headings are too short to count
+			name: "short bulleted resume",
+			text: "Jane Doe\n\nSUMMARY\nI build fast web services.\n\nEXPERIENCE\n• Cut the build time in half\n• Led a team of four",
+			want: Readability{
+				WordCount: 20, SentenceCount: 3, BulletCount: 2, LineCount: 6,
+				AvgSentenceLength: 5.3, AvgBulletLength: 5.5,
+				FleschReadingEase: 100, FleschKincaidGrade: 0,
+				EstimatedPages: 0.1, BulletDensity: 0.33,
+			},
+		},
+		{
+			// One sentence of 8 words and 34 syllables
+			name: "dense paragraph",
+			text: "Engineered scalable distributed infrastructure, significantly improving organizational productivity.",
+			want: Readability{
+				WordCount: 8, SentenceCount: 1, LineCount: 1,
+				AvgSentenceLength: 8, FleschReadingEase: 0, FleschKincaidGrade: 37.7,
+				EstimatedPages: 0,
+			},
+		},
+		{
+			// A bullet wrapped onto a second line is one bullet of 8 words and 13 syllables
+			name: "wrapped bullet",
+			text: "• Migrated the billing service to Go\n  and Postgres",
+			want: Readability{
+				WordCount: 8, SentenceCount: 1, BulletCount: 1, LineCount: 2,
+				AvgSentenceLength: 8, AvgBulletLength: 8, FleschReadingEase: 61.2, FleschKincaidGrade: 6.7,
+				BulletDensity: 0.5,
+			},
+		},
+		{
+			// Lines ending in a full stop are separate paragraphs, not wrapped ones
+			name: "pages by word count",
+			text: strings.Repeat(strings.Repeat("word ", 99)+"word.\n", 12),
+			want: Readability{
+				WordCount: 1200, SentenceCount: 12, LineCount: 12,
+				AvgSentenceLength: 100, FleschReadingEase: 20.7, FleschKincaidGrade: 35.2,
+				EstimatedPages: 2.4,
+			},
+		},
+		{
+			name: "pages by line count",
+			text: strings.Repeat("Go\n", 120),
+			want: Readability{WordCount: 120, LineCount: 120, EstimatedPages: 2.4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MeasureReadability(tt.text); got != tt.want {
+				t.Errorf("MeasureReadability() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package nlp
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Work authorization statements found in a resume
+const (
+	AuthorizationStated     = "authorized"  // The candidate states they may work without sponsorship
+	AuthorizationSponsoring = "sponsorship" // The candidate states they need visa sponsorship
+	AuthorizationUnknown    = "unknown"
+)
+
+// maxRequiredYears ignores numbers too large to be a years-of-experience requirement
+const maxRequiredYears = 25
+
+// JobRequirements are the hard requirements an ATS could screen candidates out on
+type JobRequirements struct {
+	MinimumYears      int    `json:"minimumYears"` // 0 when the JD names no minimum
+	MinimumYearsText  string `json:"minimumYearsText,omitempty"`
+	WorkAuthorization bool   `json:"workAuthorization"` // The JD requires authorization to work
+	NoSponsorship     bool   `json:"noSponsorship"`     // The JD states visas will not be sponsored
+	AuthorizationText string `json:"authorizationText,omitempty"`
+	// RequiredCertifications lists each certification requirement as its alternatives, e.g.
+	// ["CISSP", "CISM"] for "CISSP or CISM"; holding any one of them meets it
+	RequiredCertifications [][]string `json:"requiredCertifications"`
+}
+
+var (
+	numberWords = map[string]int{"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "twelve": 12, "fifteen": 15}
+
+	// minimumYearsPattern matches "5+ years of experience", "at least three years' professional
+	// experience" or "minimum of 3 years working in"
+	minimumYearsPattern = regexp.MustCompile(`(?i)\b(\d{1,2}|one|two|three|four|five|six|seven|eight|nine|ten|twelve|fifteen)\s*(?:\+|plus)?\s*` +
+		`(?:(?:-|–|to)\s*\d{1,2}\s*)?(?:years?|yrs?)['’]?\s+(?:of\s+)?(?:[\w/-]+\s+){0,4}?(?:experience|working)\b`)
+
+	// authorizationPattern matches a JD requiring the right to work
+	authorizationPattern = regexp.MustCompile(`(?i)\b(?:(?:authori[sz]ed|eligible|legally able|right) to work|work authori[sz]ation|` +
+		`(?:u\.?s\.?|us|eu|uk) citizenship (?:is )?required|must (?:be|hold) (?:a )?(?:u\.?s\.? |us )?(?:citizen|permanent resident))\b`)
+	// noSponsorshipPattern matches a JD ruling out visa sponsorship
+	noSponsorshipPattern = regexp.MustCompile(`(?i)\b(?:(?:no|not|unable to|cannot|can't|will not|won't|does not|do not)\s+(?:\w+\s+){0,3}?` +
+		`sponsor(?:ship)?|sponsorship (?:is )?not (?:available|provided|offered))\b`)
+
+	// resumeNoSponsorshipPattern matches a candidate stating they don't need sponsorship; it is
+	// checked first so "do not require sponsorship" is not read as needing it
+	resumeNoSponsorshipPattern = regexp.MustCompile(`(?i)\b(?:(?:do|does|will) not (?:require|need)|without (?:the )?need for|` +
+		`no need for)\s+(?:[\w/-]+\s+){0,2}?sponsorship\b|\bno (?:visa )?sponsorship (?:required|needed)\b`)
+	// resumeSponsorshipPattern matches a candidate stating they need sponsorship
+	resumeSponsorshipPattern = regexp.MustCompile(`(?i)\b(?:requires?|requiring|need|needs|seeking)\s+(?:[\w/-]+\s+){0,2}?sponsorship\b|` +
+		`\bsponsorship (?:required|needed)\b`)
+	// resumeAuthorizedPattern matches a candidate stating they may work in the country
+	resumeAuthorizedPattern = regexp.MustCompile(`(?i)\b(?:authori[sz]ed to work|eligible to work|right to work|` +
+		`(?:u\.?s\.?|us) citizen(?:ship)?|green card(?: holder)?|permanent resident)\b`)
+
+	// requiredPattern marks a line that states a requirement
+	requiredPattern = regexp.MustCompile(`(?i)\b(?:required|requires?|requirements?|must|mandatory|essential|necessary|minimum|needs?)\b`)
+	// requiredHeadingPattern matches a heading introducing a list of requirements
+	requiredHeadingPattern = regexp.MustCompile(`(?i)^(?:(?:minimum|basic|required|key)\s+)?(?:requirements|qualifications)\b|` +
+		`^(?:must[- ]haves?|what you(?:'ll)? need|what you bring|you have|you bring)\b`)
+	// alternativeGapPattern matches the text between certifications in a list, e.g. ", ", " or " or "/"
+	alternativeGapPattern = regexp.MustCompile(`(?i)^[\s,;]*(?:(?:certifications?|certificates?|certified)[\s,;]*)?` +
+		`(?:(?:and/or|or|and|/|&)[\s,;]*)?(?:(?:an?|the|either)\s+)?$`)
+	// orPattern marks a list gap that offers a choice
+	orPattern = regexp.MustCompile(`(?i)\bor\b|/`)
+)
+
+// maxRequirementHeadingWords is the longest line read as a heading of a requirements list
+const maxRequirementHeadingWords = 6
+
+// Kinds of JD blocks, set by the heading above them
+const (
+	blockOther = iota
+	blockRequired
+	blockPreferred
+)
+
+// ExtractJobRequirements finds the minimum experience, work authorization and certifications
+// a JD requires. Lines marking a preference ("nice to have"), and lines under a preferred
+// heading, are left out. A certification is only required on a line that states a requirement
+// or sits under a requirements heading.
+func ExtractJobRequirements(jdText string) JobRequirements {
+	requirements := JobRequirements{RequiredCertifications: make([][]string, 0)}
+	seenGroups := make(map[string]bool)
+	block := blockOther
+
+	for _, line := range strings.Split(jdText, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if kind, ok := requirementHeading(line); ok {
+			block = kind
+			continue
+		}
+		if block == blockPreferred || preferredPattern.MatchString(line) {
+			continue
+		}
+
+		// The largest minimum is the overall bar; smaller ones are usually for single skills
+		for _, match := range minimumYearsPattern.FindAllStringSubmatch(line, -1) {
+			years, ok := numberWords[strings.ToLower(match[1])]
+			if !ok {
+				years, _ = strconv.Atoi(match[1])
+			}
+			if years > requirements.MinimumYears && years <= maxRequiredYears {
+				requirements.MinimumYears = years
+				requirements.MinimumYearsText = line
+			}
+		}
+
+		if noSponsorshipPattern.MatchString(line) {
+			requirements.NoSponsorship = true
+			requirements.WorkAuthorization = true
+			requirements.AuthorizationText = line
+		} else if authorizationPattern.MatchString(line) && !requirements.WorkAuthorization {
+			requirements.WorkAuthorization = true
+			requirements.AuthorizationText = line
+		}
+
+		if block == blockRequired || requiredPattern.MatchString(line) {
+			for _, group := range certificationGroups(line) {
+				if key := strings.Join(group, "|"); !seenGroups[key] {
+					seenGroups[key] = true
+					requirements.RequiredCertifications = append(requirements.RequiredCertifications, group)
+				}
+			}
+		}
+	}
+
+	return requirements
+}
+
+// requirementHeading reports whether a line is a heading and which kind of block it starts.
+// Short lines ending in a colon are headings of other blocks, such as "Benefits:".
+func requirementHeading(line string) (int, bool) {
+	body := strings.TrimSpace(strings.TrimRight(line, ":"))
+	if len(strings.Fields(body)) > maxRequirementHeadingWords {
+		return blockOther, false
+	}
+	switch {
+	case preferredPattern.MatchString(body) && (strings.HasSuffix(line, ":") || !strings.ContainsAny(body, ".,")):
+		return blockPreferred, true
+	case requiredHeadingPattern.MatchString(body) && (strings.HasSuffix(line, ":") || !strings.ContainsAny(body, ".,")):
+		return blockRequired, true
+	case strings.HasSuffix(line, ":"):
+		return blockOther, true
+	}
+	return blockOther, false
+}
+
+// certificationGroups splits the certifications on a line into requirements. Certifications
+// listed with "or" or "/" are alternatives ("CISSP or CISM"); otherwise each is required.
+func certificationGroups(line string) [][]string {
+	matches := findCertifications(line, true)
+	groups := make([][]string, 0)
+
+	for i := 0; i < len(matches); {
+		// Collect a run of certifications separated only by list punctuation
+		run := []certificationMatch{matches[i]}
+		choice := false
+		j := i + 1
+		for ; j < len(matches); j++ {
+			gap := line[matches[j-1].end:matches[j].start]
+			if !alternativeGapPattern.MatchString(gap) {
+				break
+			}
+			choice = choice || orPattern.MatchString(gap)
+			run = append(run, matches[j])
+		}
+		i = j
+
+		if choice {
+			group := make([]string, 0, len(run))
+			for _, m := range run {
+				if !slices.Contains(group, m.certification) {
+					group = append(group, m.certification)
+				}
+			}
+			groups = append(groups, group)
+			continue
+		}
+		for _, m := range run {
+			groups = append(groups, []string{m.certification})
+		}
+	}
+
+	return groups
+}
+
+// DetectWorkAuthorization reads whether a resume states the candidate's right to work.
+// A stated need for sponsorship wins over a mention of being authorized, unless the
+// resume says sponsorship is not needed.
+func DetectWorkAuthorization(resumeText string) string {
+	switch {
+	case resumeNoSponsorshipPattern.MatchString(resumeText):
+		return AuthorizationStated
+	case resumeSponsorshipPattern.MatchString(resumeText):
+		return AuthorizationSponsoring
+	case resumeAuthorizedPattern.MatchString(resumeText):
+		return AuthorizationStated
+	default:
+		return AuthorizationUnknown
+	}
+}
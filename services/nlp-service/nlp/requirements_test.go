@@ -0,0 +1,64 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractJobRequirementsCertifications(t *testing.T) {
+	const (
+		cissp = "Certified Information Systems Security Professional"
+		cism  = "Certified Information Security Manager"
+	)
+
+	tests := []struct {
+		name string
+		jd   string
+		want [][]string
+	}{
+		{
+			name: "alternatives joined by or",
+			jd:   "CISSP or CISM certification required",
+			want: [][]string{{cissp, cism}},
+		},
+		{
+			name: "alternatives joined by a slash",
+			jd:   "Must hold CISSP/CISM",
+			want: [][]string{{cissp, cism}},
+		},
+		{
+			name: "certifications joined by and are each required",
+			jd:   "Requires CISSP and CISM",
+			want: [][]string{{cissp}, {cism}},
+		},
+		{
+			name: "certification named without a requirement",
+			jd:   "Our team includes CISSP holders and works with security leaders.",
+			want: [][]string{},
+		},
+		{
+			name: "certification under a requirements heading",
+			jd:   "Requirements:\n- CISSP or CISM certification\n- Strong communication",
+			want: [][]string{{cissp, cism}},
+		},
+		{
+			name: "certification under a preferred heading",
+			jd:   "Nice to have:\n- CISSP certification is required for senior roles",
+			want: [][]string{},
+		},
+		{
+			name: "requirements block ends at the next heading",
+			jd:   "Requirements:\n- 5+ years in security\nAbout us:\n- Several staff hold the CISSP",
+			want: [][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractJobRequirements(tt.jd).RequiredCertifications
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RequiredCertifications = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,24 +1,73 @@
 package nlp
 
 import (
-	"regexp"
+	"sort"
 	"strings"
+	"unicode"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+)
+
+// SectionPriority orders sections for breaking ties between equally long heading matches
+var SectionPriority = sharedlang.SectionPriority
+
+// continuousTextConfidence is the confidence given to headings found in text without line breaks
+const continuousTextConfidence = 0.5
+
+// SectionPattern matches the headings that introduce one section
+type SectionPattern = sharedlang.SectionPattern
+
+// HeadingMatch is the result of classifying a heading line
+type HeadingMatch = sharedlang.HeadingMatch
+
+// SectionPatterns regex patterns for English section headers, in priority order; headings for
+// every language live in the shared language files under "sectionHeadings"
+var SectionPatterns = GetLanguage(DefaultLanguage).SectionPatterns()
+
+// Block names for content outside a recognized section
+const (
+	// UnknownSection holds the content before the first heading, usually contact details
+	UnknownSection = "unknown"
+	// OtherSection holds the content under a heading that matches no known section
+	OtherSection = "other"
 )
 
-// SectionPatterns regex patterns for section headers
-var SectionPatterns = map[string]*regexp.Regexp{
-	"skills":         regexp.MustCompile(`(?i)(technical\s+skills?|skills?|core\s+competencies|expertise|proficiencies)`),
-	"experience":     regexp.MustCompile(`(?i)(work\s+experience|professional\s+experience|experience|employment\s+history|work\s+history)`),
-	"education":      regexp.MustCompile(`(?i)(education|academic|qualifications|degrees?)`),
-	"projects":       regexp.MustCompile(`(?i)(projects?|portfolio|personal\s+projects?|academic\s+projects?)`),
-	"summary":        regexp.MustCompile(`(?i)(summary|profile|objective|about\s+me|professional\s+summary)`),
-	"certifications": regexp.MustCompile(`(?i)(certifications?|certificates?|licenses?|credentials?)`),
-	"achievements":   regexp.MustCompile(`(?i)(achievements?|accomplishments?|awards?|honors?)`),
+// SectionBlock is one heading and the lines under it, in document order
+type SectionBlock struct {
+	Name       string   `json:"name"`       // Section name, UnknownSection or OtherSection
+	Heading    string   `json:"heading"`    // Heading line as written, empty for UnknownSection
+	Content    string   `json:"content"`    // Non-empty lines under the heading, one per line
+	StartLine  int      `json:"startLine"`  // 1-based line of the heading, or of the first line without one
+	EndLine    int      `json:"endLine"`    // 1-based last line of the block
+	Confidence float64  `json:"confidence"` // Heading score, 0 for blocks without a recognized heading
+	lines      []string // Non-empty lines under the heading
+}
+
+// ClassifyHeading picks the section whose heading pattern covers the longest part of line.
+// Ties go to the section listed first in SectionPriority, so the result never depends on map order.
+// The parser classifies headings with the same shared implementation.
+func ClassifyHeading(line string, patterns []SectionPattern) (HeadingMatch, bool) {
+	return sharedlang.ClassifyHeading(line, patterns)
 }
 
-// ClassifySections identifies and extracts resume sections
+// ClassifySections identifies and extracts resume sections using English headings
 func ClassifySections(text string) map[string]string {
+	return ClassifySectionsLanguage(text, DefaultLanguage)
+}
+
+// ClassifySectionsLanguage identifies and extracts resume sections using the headings of a language
+func ClassifySectionsLanguage(text, lang string) map[string]string {
+	sections, _ := ClassifySectionsWithConfidence(text, lang, nil)
+	return sections
+}
+
+// ClassifySectionsWithConfidence extracts resume sections along with the confidence of each
+// section's heading. headingHints are lines the parser found in bold or larger fonts.
+// The same text always produces the same result.
+func ClassifySectionsWithConfidence(text, lang string, headingHints []string) (map[string]string, map[string]float64) {
 	sections := make(map[string]string)
+	confidence := make(map[string]float64)
+	patterns := GetLanguage(lang).SectionPatterns()
 
 	// First, try line-by-line detection
 	lines := strings.Split(text, "\n")
@@ -26,62 +75,174 @@ func ClassifySections(text string) map[string]string {
 	// If text has no newlines, try splitting by common section patterns
 	if len(lines) <= 1 {
 		// Try to find section headers in continuous text
-		return classifySectionsFromContinuousText(text)
+		return classifySectionsFromContinuousText(text, patterns)
 	}
 
-	currentSection := "unknown"
-	var currentContent strings.Builder
+	// Repeated sections are joined so a second block never overwrites the first
+	for _, block := range splitSectionBlocks(lines, patterns, sharedlang.HeadingHints(headingHints)) {
+		if block.Content == "" {
+			continue
+		}
+		if existing, ok := sections[block.Name]; ok {
+			sections[block.Name] = existing + "\n" + block.Content
+		} else {
+			sections[block.Name] = block.Content
+		}
+		if block.Confidence > confidence[block.Name] {
+			confidence[block.Name] = block.Confidence
+		}
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+	// If we didn't find many sections, try the continuous text approach as fallback
+	if len(sections) <= 1 {
+		fallbackSections, fallbackConfidence := classifySectionsFromContinuousText(text, patterns)
+		for k, v := range fallbackSections {
+			if _, exists := sections[k]; !exists {
+				sections[k] = v
+				confidence[k] = fallbackConfidence[k]
+			}
+		}
+	}
+
+	return sections, confidence
+}
+
+// ExtractSectionBlocks splits the resume into its heading blocks in document order, keeping
+// repeated sections, content before the first heading and blocks under unrecognized headings
+func ExtractSectionBlocks(text, lang string, headingHints []string) []SectionBlock {
+	return splitSectionBlocks(strings.Split(text, "\n"), GetLanguage(lang).SectionPatterns(), sharedlang.HeadingHints(headingHints))
+}
+
+// splitSectionBlocks groups non-empty lines under the section header that precedes them
+func splitSectionBlocks(lines []string, patterns []SectionPattern, hints map[string]bool) []SectionBlock {
+	var blocks []SectionBlock
+	var current *SectionBlock
+	style := recognizedHeadingStyle(lines, patterns, hints)
+	blankBefore := true
+
+	save := func() {
+		if current != nil && (current.Heading != "" || len(current.lines) > 0) {
+			current.Content = strings.Join(current.lines, "\n")
+			blocks = append(blocks, *current)
+		}
+	}
+
+	for i, rawLine := range lines {
+		line := strings.TrimSpace(rawLine)
 		if line == "" {
+			blankBefore = true
 			continue
 		}
+		ctx := HeadingContext{BlankBefore: blankBefore, Emphasized: hints[sharedlang.NormalizeLine(line)]}
+		blankBefore = false
 
-		// Check if this line is a section header
-		foundSection := false
-		for sectionName, pattern := range SectionPatterns {
-			// Match if line is short (likely a header) and matches pattern
-			if pattern.MatchString(line) && len(line) < 60 {
-				// Save previous section
-				if currentContent.Len() > 0 {
-					sections[currentSection] = strings.TrimSpace(currentContent.String())
+		// Match if line is short and reads like a heading rather than body text
+		if len(line) < sharedlang.MaxHeadingLength {
+			if match, ok := ClassifyHeading(line, patterns); ok {
+				if score := ScoreHeading(line, match, ctx); score >= HeadingThreshold {
+					save()
+					current = &SectionBlock{Name: match.Section, Heading: line, StartLine: i + 1, EndLine: i + 1, Confidence: score}
+					continue
 				}
-				currentSection = sectionName
-				currentContent.Reset()
-				foundSection = true
-				break
+			} else if current != nil && style.matches(line, ctx) {
+				// Styled like the resume's other headings but not a section we know
+				save()
+				current = &SectionBlock{Name: OtherSection, Heading: line, StartLine: i + 1, EndLine: i + 1}
+				continue
 			}
 		}
 
-		if !foundSection && currentSection != "" {
-			currentContent.WriteString(line)
-			currentContent.WriteString(" ")
+		if current == nil {
+			current = &SectionBlock{Name: UnknownSection, StartLine: i + 1}
 		}
+		current.lines = append(current.lines, line)
+		current.EndLine = i + 1
 	}
 
-	// Save last section
-	if currentContent.Len() > 0 {
-		sections[currentSection] = strings.TrimSpace(currentContent.String())
+	save()
+
+	return blocks
+}
+
+// headingStyle is the formatting shared by all recognized headings in a resume
+type headingStyle struct {
+	allCaps    bool
+	colon      bool
+	emphasized bool
+}
+
+// recognizedHeadingStyle finds the formatting every recognized heading shares,
+// so unrecognized headings written the same way can be spotted
+func recognizedHeadingStyle(lines []string, patterns []SectionPattern, hints map[string]bool) headingStyle {
+	style := headingStyle{allCaps: true, colon: true, emphasized: true}
+	found := false
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || len(line) >= sharedlang.MaxHeadingLength {
+			continue
+		}
+		emphasized := hints[sharedlang.NormalizeLine(line)]
+		match, ok := ClassifyHeading(line, patterns)
+		if !ok || ScoreHeading(line, match, HeadingContext{Emphasized: emphasized}) < HeadingThreshold {
+			continue
+		}
+		found = true
+		style.allCaps = style.allCaps && isAllCaps(line)
+		style.colon = style.colon && strings.HasSuffix(line, ":")
+		style.emphasized = style.emphasized && emphasized
 	}
 
-	// If we didn't find many sections, try the continuous text approach as fallback
-	if len(sections) <= 1 {
-		fallbackSections := classifySectionsFromContinuousText(text)
-		for k, v := range fallbackSections {
-			if _, exists := sections[k]; !exists {
-				sections[k] = v
+	if !found {
+		return headingStyle{}
+	}
+	return style
+}
+
+// matches reports whether a line that matches no heading pattern is formatted like the
+// recognized headings. Resumes with plainly formatted headings never match.
+func (s headingStyle) matches(line string, ctx HeadingContext) bool {
+	if !s.allCaps && !s.colon && !s.emphasized {
+		return false
+	}
+	body := strings.TrimSuffix(line, ":")
+	if sharedlang.IsBullet(line) || len(strings.Fields(body)) > sharedlang.MaxHeadingWords || strings.ContainsAny(body, ".,;!?%0123456789") {
+		return false
+	}
+	return (!s.allCaps || isAllCaps(line)) &&
+		(!s.colon || strings.HasSuffix(line, ":")) &&
+		(!s.emphasized || ctx.Emphasized)
+}
+
+// isAllCaps reports whether a line has letters and all of them are upper case
+func isAllCaps(line string) bool {
+	letters := 0
+	for _, r := range line {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
 			}
+			letters++
 		}
 	}
+	return letters > 0
+}
 
-	return sections
+// sectionLines returns the lines of every block classified as the named section
+func sectionLines(text, name, lang string) []string {
+	var lines []string
+	for _, block := range splitSectionBlocks(strings.Split(text, "\n"), GetLanguage(lang).SectionPatterns(), nil) {
+		if block.Name == name {
+			lines = append(lines, block.lines...)
+		}
+	}
+	return lines
 }
 
 // classifySectionsFromContinuousText extracts sections from text without clear line breaks
-func classifySectionsFromContinuousText(text string) map[string]string {
+func classifySectionsFromContinuousText(text string, patterns []SectionPattern) (map[string]string, map[string]float64) {
 	sections := make(map[string]string)
-	textLower := strings.ToLower(text)
+	confidence := make(map[string]float64)
 
 	// Find positions of section headers
 	type sectionPos struct {
@@ -92,21 +253,36 @@ func classifySectionsFromContinuousText(text string) map[string]string {
 
 	var positions []sectionPos
 
-	for sectionName, pattern := range SectionPatterns {
-		loc := pattern.FindStringIndex(textLower)
-		if loc != nil {
-			positions = append(positions, sectionPos{name: sectionName, start: loc[0], end: loc[1]})
+	for _, section := range patterns {
+		for _, loc := range sharedlang.FindHeadings(section.Pattern, text) {
+			positions = append(positions, sectionPos{name: section.Name, start: loc[0], end: loc[1]})
 		}
 	}
 
-	// Sort by position
-	for i := 0; i < len(positions); i++ {
-		for j := i + 1; j < len(positions); j++ {
-			if positions[j].start < positions[i].start {
-				positions[i], positions[j] = positions[j], positions[i]
-			}
+	// Sort by position; at the same position the longer heading wins, then priority order
+	sort.SliceStable(positions, func(i, j int) bool {
+		if positions[i].start != positions[j].start {
+			return positions[i].start < positions[j].start
+		}
+		return positions[i].end > positions[j].end
+	})
+
+	// Drop headings that overlap a longer one, e.g. "academic" inside "academic projects",
+	// then keep the first heading of each section
+	kept := positions[:0]
+	seen := make(map[string]bool)
+	lastEnd := 0
+	for _, pos := range positions {
+		if pos.start < lastEnd {
+			continue
+		}
+		lastEnd = pos.end
+		if !seen[pos.name] {
+			seen[pos.name] = true
+			kept = append(kept, pos)
 		}
 	}
+	positions = kept
 
 	// Extract content between sections
 	for i, pos := range positions {
@@ -122,11 +298,13 @@ func classifySectionsFromContinuousText(text string) map[string]string {
 			content := strings.TrimSpace(text[start:end])
 			if len(content) > 10 { // Only add if there's meaningful content
 				sections[pos.name] = content
+				// Headings found inside running text are less certain than heading lines
+				confidence[pos.name] = continuousTextConfidence
 			}
 		}
 	}
 
-	return sections
+	return sections, confidence
 }
 
 // GetSectionQuality evaluates the quality of a section
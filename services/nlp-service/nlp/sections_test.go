@@ -0,0 +1,163 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+// determinismRuns is how many times each input is classified; map iteration order changes
+// between runs, so a result that depends on it shows up as a difference
+const determinismRuns = 50
+
+func TestClassifyHeading(t *testing.T) {
+	patterns := GetLanguage(DefaultLanguage).SectionPatterns()
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"Experience", "experience"},
+		{"Work Experience", "experience"},
+		{"Academic Projects", "projects"},
+		{"Technical Skills", "skills"},
+		{"Education & Certifications", "certifications"},
+		{"Skills & Experience", "experience"},
+		{"Professional Summary", "summary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			for i := 0; i < determinismRuns; i++ {
+				match, ok := ClassifyHeading(tt.line, patterns)
+				if !ok || match.Section != tt.want {
+					t.Fatalf("run %d: ClassifyHeading(%q) = %q, want %q", i, tt.line, match.Section, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestClassifySectionsDeterministic(t *testing.T) {
+	tests := []struct {
+		name           string
+		lang           string
+		text           string
+		wantSections   map[string]string
+		wantConfidence map[string]float64
+		wantBlocks     []string // Each block as "name: heading"
+	}{
+		{
+			name: "standard headings",
+			lang: "en",
+			text: "Jane Doe\njane@example.com\n\nSUMMARY\nBackend engineer.\n\nEXPERIENCE\nAcme 2020 - Present\n- Built APIs in Go\n\n" +
+				"EDUCATION\nBSc Computer Science\n\nSKILLS\nGo, Python, AWS",
+			wantSections: map[string]string{
+				"unknown":    "Jane Doe\njane@example.com",
+				"summary":    "Backend engineer.",
+				"experience": "Acme 2020 - Present\n- Built APIs in Go",
+				"education":  "BSc Computer Science",
+				"skills":     "Go, Python, AWS",
+			},
+			wantConfidence: map[string]float64{"summary": 0.95, "experience": 0.95, "education": 0.95, "skills": 0.95},
+			wantBlocks:     []string{"unknown: ", "summary: SUMMARY", "experience: EXPERIENCE", "education: EDUCATION", "skills: SKILLS"},
+		},
+		{
+			name: "ambiguous and repeated headings",
+			lang: "en",
+			text: "Skills & Experience\nGo, Kubernetes\n\nAcademic Projects\nCompiler in Rust\n\nEducation & Certifications\nBSc, CKA\n\n" +
+				"Experience\nGlobex 2018 - 2020\n\nSkills\nTerraform",
+			wantSections: map[string]string{
+				"experience":     "Go, Kubernetes\nGlobex 2018 - 2020",
+				"projects":       "Compiler in Rust",
+				"certifications": "BSc, CKA",
+				"skills":         "Terraform",
+			},
+			wantConfidence: map[string]float64{"experience": 0.95, "projects": 0.95, "certifications": 0.72, "skills": 0.95},
+			wantBlocks: []string{
+				"experience: Skills & Experience", "projects: Academic Projects", "certifications: Education & Certifications",
+				"experience: Experience", "skills: Skills",
+			},
+		},
+		{
+			name: "unrecognized heading",
+			lang: "en",
+			text: "EXPERIENCE\nAcme\n\nVOLUNTEERING\nFood bank\n\nEDUCATION\nMassachusetts Institute of Technology",
+			wantSections: map[string]string{
+				"experience": "Acme",
+				"other":      "Food bank",
+				"education":  "Massachusetts Institute of Technology",
+			},
+			wantConfidence: map[string]float64{"experience": 0.95, "education": 0.95},
+			wantBlocks:     []string{"experience: EXPERIENCE", "other: VOLUNTEERING", "education: EDUCATION"},
+		},
+		{
+			name: "spanish headings",
+			lang: "es",
+			text: "Experiencia Profesional\nAcme 2020 - 2023\n\nFormación Académica\nUniversidad de Madrid\n\nHabilidades\nPython, SQL",
+			wantSections: map[string]string{
+				"experience": "Acme 2020 - 2023",
+				"education":  "Universidad de Madrid",
+				"skills":     "Python, SQL",
+			},
+			wantConfidence: map[string]float64{"experience": 0.95, "education": 0.95, "skills": 0.95},
+			wantBlocks:     []string{"experience: Experiencia Profesional", "education: Formación Académica", "skills: Habilidades"},
+		},
+		{
+			// Headings inside running text are found by position; "MIT." is too short to keep
+			name: "continuous text",
+			lang: "en",
+			text: "Summary Backend engineer. Experience Acme since 2020. Education MIT. Skills Go and Python.",
+			wantSections: map[string]string{
+				"summary":    "Backend engineer.",
+				"experience": "Acme since 2020.",
+				"skills":     "Go and Python.",
+			},
+			wantConfidence: map[string]float64{"summary": 0.5, "experience": 0.5, "skills": 0.5},
+			wantBlocks:     []string{"unknown: "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < determinismRuns; i++ {
+				sections, confidence := ClassifySectionsWithConfidence(tt.text, tt.lang, nil)
+				if !reflect.DeepEqual(sections, tt.wantSections) {
+					t.Fatalf("run %d: sections = %q, want %q", i, sections, tt.wantSections)
+				}
+				if !reflect.DeepEqual(confidence, tt.wantConfidence) {
+					t.Fatalf("run %d: confidence = %v, want %v", i, confidence, tt.wantConfidence)
+				}
+
+				blocks := make([]string, 0, len(tt.wantBlocks))
+				for _, block := range ExtractSectionBlocks(tt.text, tt.lang, nil) {
+					blocks = append(blocks, block.Name+": "+block.Heading)
+				}
+				if !reflect.DeepEqual(blocks, tt.wantBlocks) {
+					t.Fatalf("run %d: blocks = %q, want %q", i, blocks, tt.wantBlocks)
+				}
+			}
+		})
+	}
+}
+
+func TestClassifySectionsKeepsLines(t *testing.T) {
+	// The scorer splits experience content into bullets by line; ats-scorer's
+	// TestScoreResumeNLPSections feeds it this same content
+	text := "Jane Doe\n\nEXPERIENCE\nSenior Engineer, Acme Jan 2020 - Present\n" +
+		"• Reduced API latency by 40% by adding a Redis cache\n" +
+		"• Worked on internal tooling for the platform team\n" +
+		"• Was responsible for code reviews across three services\n\nSKILLS\nGo, Redis"
+	want := "Senior Engineer, Acme Jan 2020 - Present\n" +
+		"• Reduced API latency by 40% by adding a Redis cache\n" +
+		"• Worked on internal tooling for the platform team\n" +
+		"• Was responsible for code reviews across three services"
+
+	sections := ClassifySections(text)
+	if sections["experience"] != want {
+		t.Errorf("experience = %q, want %q", sections["experience"], want)
+	}
+	blocks := ExtractSectionBlocks(text, DefaultLanguage, nil)
+	if len(blocks) != 3 || blocks[1].Content != want {
+		t.Errorf("blocks = %+v, want the experience block to hold %q", blocks, want)
+	}
+}
@@ -14,7 +14,15 @@ type Document struct {
 
 // NewDocument creates a document from text
 func NewDocument(text string) *Document {
-	tokens := Tokenize(text)
+	return newDocumentFromTokens(text, Tokenize(text))
+}
+
+// NewLanguageDocument creates a document using a language's stop words and stemmer
+func NewLanguageDocument(text, lang string) *Document {
+	return newDocumentFromTokens(text, GetLanguage(lang).Terms(text))
+}
+
+func newDocumentFromTokens(text string, tokens []string) *Document {
 	tf := calculateTF(tokens)
 	return &Document{
 		Text:   text,
@@ -104,9 +112,16 @@ func cosineSimilarity(vec1, vec2 map[string]float64) float64 {
 
 // CalculateSimilarity computes similarity between resume and job description
 func CalculateSimilarity(resumeText, jdText string) float64 {
-	resumeDoc := NewDocument(resumeText)
-	jdDoc := NewDocument(jdText)
+	return documentSimilarity(NewDocument(resumeText), NewDocument(jdText))
+}
+
+// CalculateLanguageSimilarity computes similarity with each text analyzed in its own language
+func CalculateLanguageSimilarity(resumeText, resumeLang, jdText, jdLang string) float64 {
+	return documentSimilarity(NewLanguageDocument(resumeText, resumeLang), NewLanguageDocument(jdText, jdLang))
+}
 
+// documentSimilarity computes TF-IDF cosine similarity between two documents, scaled to 0-100
+func documentSimilarity(resumeDoc, jdDoc *Document) float64 {
 	docs := []*Document{resumeDoc, jdDoc}
 	idf := calculateIDF(docs)
 
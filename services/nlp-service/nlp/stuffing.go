@@ -0,0 +1,162 @@
+package nlp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Keyword stuffing thresholds
+const (
+	// StuffingMinCount is the minimum number of repetitions before a term is suspicious
+	StuffingMinCount = 6
+	// StuffingMaxDensity is the share of resume tokens a single JD term may take up
+	StuffingMaxDensity = 0.03
+	// StuffingMinTokens is the resume length the density is measured against at least, so a
+	// short resume naming its main skill in every position isn't read as stuffed
+	StuffingMinTokens = 300
+	// CopiedNGramSize is the minimum run of words shared verbatim with the JD to count as copied
+	CopiedNGramSize = 8
+	// CopiedWordLimit is the number of copied words after which copying is flagged
+	CopiedWordLimit = 30
+	// CopiedRatioLimit is the share of copied resume words after which copying is flagged
+	CopiedRatioLimit = 0.10
+	// maxDensityTerms limits how many densities are returned
+	maxDensityTerms = 20
+)
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}+#]+`)
+
+// KeywordDensity represents how often a term appears in the resume
+type KeywordDensity struct {
+	Term    string  `json:"term"`
+	Count   int     `json:"count"`
+	Density float64 `json:"density"` // Share of all resume tokens, 0-1
+}
+
+// StuffingReport summarizes keyword stuffing and JD copying signals
+type StuffingReport struct {
+	Densities       []KeywordDensity `json:"densities"`
+	RepeatedTerms   []string         `json:"repeatedTerms"`
+	CopiedPhrases   []string         `json:"copiedPhrases"`
+	CopiedWords     int              `json:"copiedWords"`
+	CopiedWordRatio float64          `json:"copiedWordRatio"`
+	Copied          bool             `json:"copied"`
+	Detected        bool             `json:"detected"`
+}
+
+// CalculateKeywordDensity computes per-term density of the text's tokens
+func CalculateKeywordDensity(text string) []KeywordDensity {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return []KeywordDensity{}
+	}
+
+	counts := make(map[string]int)
+	for _, token := range tokens {
+		counts[token]++
+	}
+
+	densities := make([]KeywordDensity, 0, len(counts))
+	for term, count := range counts {
+		densities = append(densities, KeywordDensity{
+			Term:    term,
+			Count:   count,
+			Density: float64(count) / float64(len(tokens)),
+		})
+	}
+
+	// Most frequent first, alphabetical for ties so output is stable
+	sort.Slice(densities, func(i, j int) bool {
+		if densities[i].Count != densities[j].Count {
+			return densities[i].Count > densities[j].Count
+		}
+		return densities[i].Term < densities[j].Term
+	})
+
+	return densities
+}
+
+// DetectKeywordStuffing looks for abnormal repetition of JD terms and text copied from the JD
+func DetectKeywordStuffing(resumeText, jdText string) StuffingReport {
+	densities := CalculateKeywordDensity(resumeText)
+
+	jdTerms := make(map[string]bool)
+	for _, token := range Tokenize(jdText) {
+		jdTerms[token] = true
+	}
+
+	// Only JD terms count as stuffing, since that is what gets gamed
+	allowed := StuffingMaxDensity * float64(max(len(Tokenize(resumeText)), StuffingMinTokens))
+	repeated := make([]string, 0)
+	for _, d := range densities {
+		if jdTerms[d.Term] && d.Count >= StuffingMinCount && float64(d.Count) > allowed {
+			repeated = append(repeated, d.Term)
+		}
+	}
+
+	phrases, copiedWords, totalWords := findCopiedPhrases(resumeText, jdText)
+
+	var ratio float64
+	if totalWords > 0 {
+		ratio = float64(copiedWords) / float64(totalWords)
+	}
+
+	copied := copiedWords >= CopiedWordLimit || ratio >= CopiedRatioLimit
+
+	if len(densities) > maxDensityTerms {
+		densities = densities[:maxDensityTerms]
+	}
+
+	return StuffingReport{
+		Densities:       densities,
+		RepeatedTerms:   repeated,
+		CopiedPhrases:   phrases,
+		CopiedWords:     copiedWords,
+		CopiedWordRatio: ratio,
+		Copied:          copied,
+		Detected:        len(repeated) > 0 || copied,
+	}
+}
+
+// findCopiedPhrases returns maximal runs of at least CopiedNGramSize words shared verbatim with the JD
+func findCopiedPhrases(resumeText, jdText string) (phrases []string, copiedWords, totalWords int) {
+	resumeWords := wordPattern.FindAllString(strings.ToLower(resumeText), -1)
+	jdWords := wordPattern.FindAllString(strings.ToLower(jdText), -1)
+	phrases = make([]string, 0)
+
+	if len(resumeWords) < CopiedNGramSize || len(jdWords) < CopiedNGramSize {
+		return phrases, 0, len(resumeWords)
+	}
+
+	jdGrams := make(map[string]bool)
+	for i := 0; i+CopiedNGramSize <= len(jdWords); i++ {
+		jdGrams[strings.Join(jdWords[i:i+CopiedNGramSize], " ")] = true
+	}
+
+	// Mark every resume word covered by a shared n-gram
+	covered := make([]bool, len(resumeWords))
+	for i := 0; i+CopiedNGramSize <= len(resumeWords); i++ {
+		if jdGrams[strings.Join(resumeWords[i:i+CopiedNGramSize], " ")] {
+			for j := i; j < i+CopiedNGramSize; j++ {
+				covered[j] = true
+			}
+		}
+	}
+
+	// Merge covered words into phrases
+	for i := 0; i < len(resumeWords); {
+		if !covered[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < len(resumeWords) && covered[i] {
+			i++
+		}
+		phrases = append(phrases, strings.Join(resumeWords[start:i], " "))
+		copiedWords += i - start
+	}
+
+	return phrases, copiedWords, len(resumeWords)
+}
@@ -0,0 +1,48 @@
+package nlp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectKeywordStuffing(t *testing.T) {
+	jd := "We are hiring a backend engineer with strong Python experience to build APIs on AWS."
+
+	honest := `Backend Engineer, Acme (2021 - Present)
+- Built Python services that process payments for 2M users
+- Migrated Python batch jobs to AWS Lambda, cutting costs 30%
+- Mentored four engineers on Python testing practices
+Software Engineer, Globex (2018 - 2021)
+- Wrote Python APIs for the inventory system
+- Automated Python deployment scripts with Jenkins
+Skills: Python, Django, PostgreSQL, AWS
+Projects: Open-source Python library for rate limiting`
+
+	stuffed := honest + "\n" + strings.Repeat("python ", 12)
+
+	// Padding a long resume with unrelated text dilutes the term below the density limit
+	long := strings.Repeat("Designed reliable distributed systems, reviewed code and improved team processes. ", 40)
+	longStuffed := long + strings.Repeat("python ", 20)
+	longHonest := long + strings.Repeat("python ", 8)
+
+	tests := []struct {
+		name   string
+		resume string
+		want   []string
+	}{
+		{"short honest resume naming its main skill seven times", honest, []string{}},
+		{"short resume with the skill padded on", stuffed, []string{"python"}},
+		{"long resume with the skill repeated past the density limit", longStuffed, []string{"python"}},
+		{"long resume with the skill repeated a few times", longHonest, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := DetectKeywordStuffing(tt.resume, jd)
+			if !reflect.DeepEqual(report.RepeatedTerms, tt.want) {
+				t.Errorf("RepeatedTerms = %q, want %q", report.RepeatedTerms, tt.want)
+			}
+		})
+	}
+}
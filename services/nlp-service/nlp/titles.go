@@ -0,0 +1,240 @@
+package nlp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Seniority levels
+const (
+	SeniorityIntern    = "intern"
+	SeniorityJunior    = "junior"
+	SeniorityMid       = "mid"
+	SenioritySenior    = "senior"
+	SeniorityStaff     = "staff"
+	SeniorityPrincipal = "principal"
+	SeniorityManager   = "manager"
+	SeniorityDirector  = "director"
+)
+
+// seniorityLevel is a seniority with its rank and the title words that signal it
+type seniorityLevel struct {
+	name    string
+	rank    int
+	pattern *regexp.Regexp
+}
+
+// seniorityLevels are checked in order, so "Senior Engineering Manager" reads as a manager
+// and "Staff Engineer" is not mistaken for "staff" in a generic sense
+var seniorityLevels = []seniorityLevel{
+	{SeniorityDirector, 6, regexp.MustCompile(`(?i)\b(director|head of|vp|vice president|cto|chief)\b`)},
+	{SeniorityManager, 4, regexp.MustCompile(`(?i)\b(manager|management)\b`)},
+	{SeniorityPrincipal, 5, regexp.MustCompile(`(?i)\b(principal|distinguished|fellow)\b`)},
+	{SeniorityStaff, 4, regexp.MustCompile(`(?i)\b(staff|lead|tech lead|team lead)\b`)},
+	{SenioritySenior, 3, regexp.MustCompile(`(?i)\b(senior|sr\.?|iii)\b`)},
+	{SeniorityJunior, 1, regexp.MustCompile(`(?i)\b(junior|jr\.?|entry[- ]level|graduate|associate|i)\b`)},
+	{SeniorityIntern, 0, regexp.MustCompile(`(?i)\b(intern|internship|trainee|apprentice)\b`)},
+	{SeniorityMid, 2, regexp.MustCompile(`(?i)\b(mid[- ]level|intermediate|ii)\b`)},
+}
+
+// roleNouns end a job title
+const roleNouns = `engineer|developer|programmer|scientist|analyst|architect|designer|administrator|consultant|` +
+	`specialist|manager|director|intern|lead|technician|tester|researcher|devops|sre|swe|sde`
+
+var (
+	// titleLabelPattern finds an explicit title in a JD, e.g. "Job Title: Senior Go Engineer"
+	titleLabelPattern = regexp.MustCompile(`(?i)^\s*(?:job\s+title|position|role|title)\s*[:\-–]\s*(.+)$`)
+	// titleIntroPattern finds a title introduced in JD prose, e.g. "We are hiring a Senior Go Engineer"
+	titleIntroPattern = regexp.MustCompile(`(?i)\b(?:looking for|hiring|seeking|join us as|join our team as|role of|position of)\s+(?:an?\s+|the\s+)?` +
+		`((?:[\w+#./-]+\s+){0,4}?(?:` + roleNouns + `)s?)\b`)
+	// rolePattern finds a role phrase of up to five words ending in a role noun
+	rolePattern = regexp.MustCompile(`(?i)((?:[\w+#./]+\s+){0,4}(?:` + roleNouns + `)s?)\b`)
+	// experienceYearsPattern finds a required number of years, e.g. "5+ years" or "3-5 years"
+	experienceYearsPattern = regexp.MustCompile(`(?i)\b(\d{1,2})\s*\+?\s*(?:-\s*\d{1,2}\s*)?\+?\s*years?\b`)
+	// titleSeparator splits position lines into the role and the company or dates
+	titleSeparator = regexp.MustCompile(`\s*(?:[,|@(]|\s[-–—]\s|\sat\s)\s*`)
+)
+
+// titleSynonyms normalize title words so equivalent titles compare equal
+var titleSynonyms = map[string]string{
+	"developer":   "engineer",
+	"programmer":  "engineer",
+	"dev":         "engineer",
+	"engineering": "engineer",
+	"swe":         "software engineer",
+	"sde":         "software engineer",
+	"sre":         "site reliability engineer",
+	"frontend":    "front-end",
+	"front":       "front-end",
+	"backend":     "back-end",
+	"back":        "back-end",
+	"fullstack":   "full-stack",
+	"full":        "full-stack",
+	"ml":          "machine learning",
+	"golang":      "go",
+}
+
+// titleStopWords carry no meaning when comparing titles
+var titleStopWords = map[string]bool{"of": true, "and": true, "the": true, "a": true, "an": true, "&": true, "for": true, "end": true, "stack": true}
+
+// TitleAlignment compares the role being hired for with the candidate's titles
+type TitleAlignment struct {
+	TargetTitle        string   `json:"targetTitle"` // Empty when the JD names no title
+	TargetSeniority    string   `json:"targetSeniority"`
+	TargetRank         int      `json:"targetRank"`
+	CandidateTitle     string   `json:"candidateTitle"` // Most recent title
+	CandidateSeniority string   `json:"candidateSeniority"`
+	CandidateRank      int      `json:"candidateRank"`
+	Titles             []string `json:"titles"`       // Title history, most recent first
+	BestMatch          string   `json:"bestMatch"`    // Candidate title closest to the target
+	Similarity         float64  `json:"similarity"`   // 0-1 similarity of BestMatch to the target, ignoring seniority
+	SeniorityGap       int      `json:"seniorityGap"` // Target rank minus candidate rank; positive means a step up
+}
+
+// DetectSeniority returns the seniority a title signals, or ok=false when it signals none
+func DetectSeniority(title string) (name string, rank int, ok bool) {
+	for _, level := range seniorityLevels {
+		if level.pattern.MatchString(title) {
+			return level.name, level.rank, true
+		}
+	}
+	return "", 0, false
+}
+
+// seniorityForYears estimates seniority from years of experience
+func seniorityForYears(years int) (string, int) {
+	switch {
+	case years >= 8:
+		return SeniorityStaff, 4
+	case years >= 5:
+		return SenioritySenior, 3
+	case years >= 2:
+		return SeniorityMid, 2
+	default:
+		return SeniorityJunior, 1
+	}
+}
+
+// ExtractTargetTitle finds the title a JD is hiring for: an explicit "Title:" line, a short
+// first line naming a role, or a role introduced in prose
+func ExtractTargetTitle(jdText string) string {
+	lines := strings.Split(jdText, "\n")
+
+	for _, line := range lines {
+		if match := titleLabelPattern.FindStringSubmatch(line); match != nil {
+			if role := rolePattern.FindString(match[1]); role != "" {
+				return strings.TrimSpace(role)
+			}
+		}
+	}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(strings.Fields(line)) <= 8 {
+			if role := rolePattern.FindString(line); role != "" {
+				return strings.TrimSpace(role)
+			}
+		}
+		break
+	}
+
+	if match := titleIntroPattern.FindStringSubmatch(jdText); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// candidateTitle extracts the role from a position title line, dropping the company
+func candidateTitle(positionTitle string) string {
+	for _, part := range titleSeparator.Split(positionTitle, -1) {
+		if role := rolePattern.FindString(part); role != "" {
+			return strings.TrimSpace(role)
+		}
+	}
+	return ""
+}
+
+// titleTerms normalizes a title into comparable words, leaving out seniority words
+func titleTerms(title string) map[string]bool {
+	for _, level := range seniorityLevels {
+		title = level.pattern.ReplaceAllString(title, " ")
+	}
+
+	terms := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !isSkillRune(r) && r != '-' && r != '.'
+	}) {
+		word = strings.Trim(word, "-.")
+		if synonym, ok := titleSynonyms[word]; ok {
+			word = synonym
+		}
+		for _, term := range strings.Fields(word) {
+			if !titleStopWords[term] {
+				terms[term] = true
+			}
+		}
+	}
+	return terms
+}
+
+// TitleSimilarity is the Dice coefficient of the normalized words of two titles, 0-1
+func TitleSimilarity(a, b string) float64 {
+	termsA, termsB := titleTerms(a), titleTerms(b)
+	if len(termsA) == 0 || len(termsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for term := range termsA {
+		if termsB[term] {
+			shared++
+		}
+	}
+	similarity := 2 * float64(shared) / float64(len(termsA)+len(termsB))
+	return float64(int(similarity*100+0.5)) / 100
+}
+
+// AlignTitles compares the JD's target title and seniority with the candidate's title history
+func AlignTitles(jdText string, positions []Position) TitleAlignment {
+	alignment := TitleAlignment{Titles: make([]string, 0)}
+
+	// Positions are listed most recent first on nearly every resume
+	for _, position := range positions {
+		if title := candidateTitle(position.Title); title != "" {
+			alignment.Titles = append(alignment.Titles, title)
+		}
+	}
+	totalYears := TotalExperienceYears(positions)
+
+	alignment.TargetTitle = ExtractTargetTitle(jdText)
+	if name, rank, ok := DetectSeniority(alignment.TargetTitle); ok {
+		alignment.TargetSeniority, alignment.TargetRank = name, rank
+	} else if match := experienceYearsPattern.FindStringSubmatch(jdText); match != nil {
+		years, _ := strconv.Atoi(match[1])
+		alignment.TargetSeniority, alignment.TargetRank = seniorityForYears(years)
+	} else {
+		alignment.TargetSeniority, alignment.TargetRank = SeniorityMid, 2
+	}
+
+	if len(alignment.Titles) > 0 {
+		alignment.CandidateTitle = alignment.Titles[0]
+	}
+	if name, rank, ok := DetectSeniority(alignment.CandidateTitle); ok {
+		alignment.CandidateSeniority, alignment.CandidateRank = name, rank
+	} else {
+		alignment.CandidateSeniority, alignment.CandidateRank = seniorityForYears(totalYears)
+	}
+	alignment.SeniorityGap = alignment.TargetRank - alignment.CandidateRank
+
+	for _, title := range alignment.Titles {
+		if similarity := TitleSimilarity(alignment.TargetTitle, title); similarity > alignment.Similarity || alignment.BestMatch == "" {
+			alignment.Similarity = similarity
+			alignment.BestMatch = title
+		}
+	}
+
+	return alignment
+}
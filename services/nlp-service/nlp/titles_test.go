@@ -0,0 +1,76 @@
+package nlp
+
+import "testing"
+
+func TestDetectSeniority(t *testing.T) {
+	tests := []struct {
+		title    string
+		wantName string
+		wantRank int
+		wantOK   bool
+	}{
+		{"Senior Engineering Manager", SeniorityManager, 4, true},
+		{"Staff Engineer", SeniorityStaff, 4, true},
+		{"Team Lead", SeniorityStaff, 4, true},
+		{"Sr. Developer", SenioritySenior, 3, true},
+		{"Software Engineer II", SeniorityMid, 2, true},
+		{"Junior Data Analyst", SeniorityJunior, 1, true},
+		{"Summer Intern", SeniorityIntern, 0, true},
+		{"Principal Engineer", SeniorityPrincipal, 5, true},
+		{"VP of Engineering", SeniorityDirector, 6, true},
+		{"Software Engineer", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			name, rank, ok := DetectSeniority(tt.title)
+			if name != tt.wantName || rank != tt.wantRank || ok != tt.wantOK {
+				t.Errorf("DetectSeniority(%q) = %q, %d, %v, want %q, %d, %v", tt.title, name, rank, ok, tt.wantName, tt.wantRank, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Senior Software Engineer", "Software Developer", 1},
+		{"Backend Engineer", "Back-End Developer", 1},
+		{"SWE", "Software Engineer", 1},
+		{"Frontend Engineer", "Full Stack Engineer", 0.5},
+		{"Senior Data Engineer", "Machine Learning Engineer", 0.4},
+		{"Data Scientist", "Software Engineer", 0},
+		{"", "Software Engineer", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := TitleSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("TitleSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTargetTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		jd   string
+		want string
+	}{
+		{"title label", "About Acme\nJob Title: Senior Go Engineer\nWe build payment APIs.", "Senior Go Engineer"},
+		{"short first line", "Backend Developer\n\nAbout us: we build payment APIs for small businesses.", "Backend Developer"},
+		{"title in prose", "Acme is growing fast and we are looking for a Staff Data Scientist to lead our ML team.", "Staff Data Scientist"},
+		{"no title", "We value teamwork and ownership across everything we do here at Acme.", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractTargetTitle(tt.jd); got != tt.want {
+				t.Errorf("ExtractTargetTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
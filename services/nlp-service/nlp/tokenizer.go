@@ -3,40 +3,46 @@ package nlp
 import (
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-// StopWords common English stop words to filter out
-var StopWords = map[string]bool{
-	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
-	"be": true, "by": true, "for": true, "from": true, "has": true, "he": true,
-	"in": true, "is": true, "it": true, "its": true, "of": true, "on": true,
-	"or": true, "that": true, "the": true, "to": true, "was": true, "were": true,
-	"will": true, "with": true, "you": true, "your": true, "we": true, "our": true,
-	"this": true, "have": true, "had": true, "but": true, "not": true, "can": true,
-	"would": true, "could": true, "should": true, "may": true, "might": true,
-	"also": true, "more": true, "some": true, "any": true, "all": true, "each": true,
-	"most": true, "other": true, "into": true, "over": true, "such": true, "then": true,
-	"than": true, "very": true, "just": true, "been": true, "being": true, "do": true,
-	"does": true, "did": true, "about": true, "after": true, "before": true, "through": true,
-}
+// StopWords common English stop words to filter out; other languages are in the shared language files
+var StopWords = StopWordsFor(DefaultLanguage)
+
+// hyphenatedLineBreak matches a word split across lines, e.g. "develop-\nment"
+var hyphenatedLineBreak = regexp.MustCompile(`(\p{L})[-\x{00AD}]\s*\n\s*(\p{L})`)
+
+// apostropheReplacer maps typographic apostrophes to a plain one and drops soft hyphens
+var apostropheReplacer = strings.NewReplacer("\u2019", "'", "\u2018", "'", "\u02BC", "'", "`", "'", "\u00AD", "")
 
-// Tokenize breaks text into normalized tokens
+// elisionPrefixes are French and Italian articles and pronouns elided onto the next word
+var elisionPrefixes = []string{"l'", "d'", "j'", "qu'", "n'", "s'", "c'", "m'", "t'", "dell'", "all'", "un'"}
+
+// Tokenize breaks text into normalized tokens using English stop words
 func Tokenize(text string) []string {
+	return TokenizeLanguage(text, DefaultLanguage)
+}
+
+// TokenizeLanguage breaks text into normalized tokens using the stop words of a language
+func TokenizeLanguage(text, lang string) []string {
+	return TokenizeWithStopWords(text, StopWordsFor(lang))
+}
+
+// TokenizeWithStopWords breaks text into lowercase tokens of letters and digits from any script.
+// Technical punctuation ("c++", "c#", "node.js", "full-stack") is kept inside tokens.
+func TokenizeWithStopWords(text string, stopWords map[string]bool) []string {
 	// Convert to lowercase
 	text = strings.ToLower(text)
 
-	// Replace punctuation with spaces
-	reg := regexp.MustCompile(`[^\w\s+#.-]`)
-	text = reg.ReplaceAllString(text, " ")
-
-	// Split into words
-	words := strings.Fields(text)
+	// Rejoin words hyphenated across line breaks and normalize apostrophes
+	text = hyphenatedLineBreak.ReplaceAllString(text, "$1$2")
+	text = apostropheReplacer.Replace(text)
 
-	// Filter and normalize
-	tokens := make([]string, 0, len(words))
-	for _, word := range words {
-		word = strings.Trim(word, ".-")
-		if len(word) >= 2 && !StopWords[word] {
+	tokens := make([]string, 0)
+	for _, word := range splitWords(text) {
+		word = normalizeApostrophes(word)
+		if utf8.RuneCountInString(word) >= 2 && !stopWords[word] {
 			tokens = append(tokens, word)
 		}
 	}
@@ -44,9 +50,76 @@ func Tokenize(text string) []string {
 	return tokens
 }
 
+// splitWords splits text into words, keeping connectors that sit between word characters
+func splitWords(text string) []string {
+	runes := []rune(text)
+	words := make([]string, 0)
+	var current []rune
+
+	flush := func() {
+		// Connectors never start or end a word
+		for len(current) > 0 && isWordConnector(current[len(current)-1]) {
+			current = current[:len(current)-1]
+		}
+		if len(current) > 0 {
+			words = append(words, string(current))
+		}
+		current = current[:0]
+	}
+
+	for i, r := range runes {
+		switch {
+		case isWordRune(r):
+			current = append(current, r)
+		case isWordConnector(r) && len(current) > 0 && i+1 < len(runes) && isWordRune(runes[i+1]):
+			current = append(current, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return words
+}
+
+// isWordRune reports whether r belongs to a word: letters and digits from any script, combining marks,
+// and the "+" and "#" used in names like "c++" and "c#"
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_' || r == '+' || r == '#'
+}
+
+// isWordConnector reports whether r joins two word parts, as in "node.js", "full-stack" or "don't"
+func isWordConnector(r rune) bool {
+	return r == '.' || r == '-' || r == '\''
+}
+
+// normalizeApostrophes strips possessives and elided articles, e.g. "team's" and "l'équipe"
+func normalizeApostrophes(word string) string {
+	if !strings.ContainsRune(word, '\'') {
+		return word
+	}
+
+	word = strings.TrimSuffix(word, "'s")
+	for _, prefix := range elisionPrefixes {
+		if strings.HasPrefix(word, prefix) && len(word) > len(prefix) {
+			return word[len(prefix):]
+		}
+	}
+
+	return word
+}
+
 // ExtractKeywords extracts significant keywords from text
 func ExtractKeywords(text string) []string {
-	tokens := Tokenize(text)
+	return extractKeywordsFromTokens(Tokenize(text))
+}
+
+// ExtractKeywordsLanguage extracts significant keywords using a language's stop words
+func ExtractKeywordsLanguage(text, lang string) []string {
+	return extractKeywordsFromTokens(TokenizeLanguage(text, lang))
+}
+
+func extractKeywordsFromTokens(tokens []string) []string {
 
 	// Count frequency
 	freq := make(map[string]int)
@@ -59,7 +132,7 @@ func ExtractKeywords(text string) []string {
 	seen := make(map[string]bool)
 
 	for _, token := range tokens {
-		if !seen[token] && freq[token] >= 1 && len(token) >= 3 {
+		if !seen[token] && freq[token] >= 1 && utf8.RuneCountInString(token) >= 3 {
 			seen[token] = true
 			keywords = append(keywords, token)
 		}
@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/gofiber/fiber/v2 v2.52.0
 	github.com/joho/godotenv v1.5.1
+	github.com/kedar/ats-checker/shared v0.0.0
 	github.com/ledongthuc/pdf v0.0.0-20220302134840-0c2507a12d80
 	github.com/nguyenthenguyen/docx v0.0.0-20230621112118-9c8e795a11db
 )
@@ -22,3 +23,5 @@ require (
 	github.com/valyala/tcplisten v1.0.0 // indirect
 	golang.org/x/sys v0.15.0 // indirect
 )
+
+replace github.com/kedar/ats-checker/shared => ../shared
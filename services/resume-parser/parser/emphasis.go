@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+	"github.com/ledongthuc/pdf"
+)
+
+// Emphasis thresholds
+const (
+	// headingSizeRatio is how much larger than the body text a line must be to count as emphasized
+	headingSizeRatio = 1.15
+	// minBoldShare is the share of a line's characters that must be bold for the line to count as bold
+	minBoldShare = 0.8
+	// lineTolerance is the vertical distance, in points, within which characters share a line
+	lineTolerance = 2.0
+)
+
+// boldFontMarkers appear in the names of bold font variants
+var boldFontMarkers = []string{"bold", "black", "heavy", "semibold", "demi"}
+
+// pdfLine is a line of text on a page with its font statistics
+type pdfLine struct {
+	text      string
+	size      float64
+	boldChars int
+	chars     int
+	cells     int // Runs of text separated by gaps wide enough to be table columns
+}
+
+// DetectEmphasizedLinesPDF finds short lines set in bold or in a font larger than the body text.
+// Section headings are usually emphasized, so nlp-service uses these lines as heading hints.
+func DetectEmphasizedLinesPDF(filePath string) ([]string, error) {
+	f, r, err := pdf.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []pdfLine
+	for pageNum := 1; pageNum <= r.NumPage(); pageNum++ {
+		page := r.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+		lines = append(lines, linesOnPage(page)...)
+	}
+
+	// The most common font size, weighted by characters, is the body text size
+	sizeChars := make(map[float64]int)
+	boldChars, totalChars := 0, 0
+	for _, line := range lines {
+		sizeChars[math.Round(line.size*2)/2] += line.chars
+		boldChars += line.boldChars
+		totalChars += line.chars
+	}
+	bodySize, mostChars := 0.0, 0
+	for size, chars := range sizeChars {
+		if chars > mostChars || (chars == mostChars && size < bodySize) {
+			bodySize, mostChars = size, chars
+		}
+	}
+	// Bold only stands out when the body text is not bold itself
+	boldStandsOut := totalChars > 0 && float64(boldChars)/float64(totalChars) < 0.5
+
+	emphasized := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		if line.chars == 0 || len(line.text) >= sharedlang.MaxHeadingLength {
+			continue
+		}
+		larger := bodySize > 0 && line.size >= bodySize*headingSizeRatio
+		bold := boldStandsOut && float64(line.boldChars)/float64(line.chars) >= minBoldShare
+		if (larger || bold) && !seen[line.text] {
+			seen[line.text] = true
+			emphasized = append(emphasized, line.text)
+		}
+	}
+
+	return emphasized, nil
+}
+
+// linesOnPage groups the characters on a page into lines, top to bottom
+func linesOnPage(page pdf.Page) (lines []pdfLine) {
+	defer func() {
+		// Malformed content streams panic inside the pdf library; keep the lines found so far
+		recover()
+	}()
+
+	texts := page.Content().Text
+	sort.SliceStable(texts, func(i, j int) bool {
+		if math.Abs(texts[i].Y-texts[j].Y) > lineTolerance {
+			return texts[i].Y > texts[j].Y
+		}
+		return texts[i].X < texts[j].X
+	})
+
+	var current pdfLine
+	var builder strings.Builder
+	lastY, lastEnd := math.Inf(1), 0.0
+
+	flush := func() {
+		current.text = NormalizeText(builder.String())
+		if current.text != "" {
+			lines = append(lines, current)
+		}
+		current = pdfLine{cells: 1}
+		builder.Reset()
+	}
+
+	for _, t := range texts {
+		if math.Abs(t.Y-lastY) > lineTolerance {
+			flush()
+			lastY = t.Y
+		} else if gap := t.X - lastEnd; gap > t.FontSize*0.2 {
+			// A gap wider than a fraction of the font size separates words, a much wider one columns
+			builder.WriteString(" ")
+			if gap > t.FontSize*tableGapRatio {
+				current.cells++
+			}
+		}
+		builder.WriteString(t.S)
+		lastEnd = t.X + t.W
+
+		if strings.TrimSpace(t.S) == "" {
+			continue
+		}
+		current.chars++
+		current.size = math.Max(current.size, t.FontSize)
+		if isBoldFont(t.Font) {
+			current.boldChars++
+		}
+	}
+	flush()
+
+	return lines
+}
+
+// isBoldFont reports whether a font name is a bold variant
+func isBoldFont(font string) bool {
+	font = strings.ToLower(font)
+	for _, marker := range boldFontMarkers {
+		if strings.Contains(font, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,9 +17,14 @@ type ParseRequest struct {
 
 // ParseResponse represents parsing result
 type ParseResponse struct {
-	Text     string   `json:"text"`
-	Sections []string `json:"sections"`
-	Error    string   `json:"error,omitempty"`
+	Text       string   `json:"text"`
+	Sections   []string `json:"sections"`
+	HiddenText []string `json:"hiddenText"` // Text a reader cannot see (white or tiny fonts)
+	// HeadingHints are lines set in bold or a larger font, likely section headings
+	HeadingHints []string `json:"headingHints"`
+	// LegacyUnread is text an older ATS parser would skip: tables and text boxes
+	LegacyUnread []string `json:"legacyUnread"`
+	Error        string   `json:"error,omitempty"`
 }
 
 // HandleParse handles the parse endpoint
@@ -65,11 +70,31 @@ func HandleParse(c *fiber.Ctx) error {
 
 	// Parse based on file type
 	var text string
+	hiddenText := make([]string, 0)
+	headingHints := make([]string, 0)
+	legacyUnread := make([]string, 0)
 	switch ext {
 	case ".pdf":
 		text, err = ParsePDF(tmpFile.Name())
+		if err == nil {
+			// Hidden text detection is best effort and never fails the parse
+			if hidden, hiddenErr := DetectHiddenTextPDF(tmpFile.Name()); hiddenErr == nil {
+				hiddenText = hidden
+			}
+			if emphasized, emphasisErr := DetectEmphasizedLinesPDF(tmpFile.Name()); emphasisErr == nil {
+				headingHints = emphasized
+			}
+			if unread, legacyErr := LegacyUnreadPDF(tmpFile.Name()); legacyErr == nil {
+				legacyUnread = unread
+			}
+		}
 	case ".docx":
 		text, err = ParseDOCX(tmpFile.Name())
+		if err == nil {
+			if unread, legacyErr := LegacyUnreadDOCX(tmpFile.Name()); legacyErr == nil {
+				legacyUnread = unread
+			}
+		}
 	default:
 		return c.Status(fiber.StatusBadRequest).JSON(ParseResponse{
 			Error: "Unsupported file type. Only PDF and DOCX are supported.",
@@ -86,10 +111,13 @@ func HandleParse(c *fiber.Ctx) error {
 	text = NormalizeText(text)
 
 	// Detect sections
-	sections := DetectSections(text)
+	sections := DetectSections(text, headingHints)
 
 	return c.JSON(ParseResponse{
-		Text:     text,
-		Sections: sections,
+		Text:         text,
+		Sections:     sections,
+		HiddenText:   hiddenText,
+		HeadingHints: headingHints,
+		LegacyUnread: legacyUnread,
 	})
 }
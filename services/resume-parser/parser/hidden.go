@@ -0,0 +1,291 @@
+package parser
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Invisible text thresholds
+const (
+	// MinVisibleFontSize is the smallest font size, in points, a reader can realistically see
+	MinVisibleFontSize = 4.0
+	// colorTolerance is how far apart each color component may be for text to match its background
+	colorTolerance = 0.05
+	// invisibleRenderMode is the PDF text render mode that paints nothing
+	invisibleRenderMode = 3
+)
+
+// color is a fill color in RGB, each component 0-1
+type color [3]float64
+
+// white is the default page background
+var white = color{1, 1, 1}
+
+// matches reports whether two colors are too close for text in one to be read on the other
+func (c color) matches(other color) bool {
+	for i := range c {
+		if math.Abs(c[i]-other[i]) > colorTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// matrix is a PDF transformation matrix [a b c d e f]
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// multiply returns m × n, applying m first
+func (m matrix) multiply(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2], m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2], m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4], m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+// apply transforms a point
+func (m matrix) apply(x, y float64) (float64, float64) {
+	return m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+}
+
+// paintedRect is a filled rectangle in page space
+type paintedRect struct {
+	minX, minY, maxX, maxY float64
+	fill                   color
+	known                  bool // False for fills such as patterns whose color can't be read
+}
+
+// textState tracks the graphics state relevant to text visibility
+type textState struct {
+	fill       color
+	fillKnown  bool
+	renderMode int
+	fontSize   float64
+	ctm        matrix
+}
+
+// hidden reports whether text drawn with this state, at the given text matrix and over the
+// given background, is invisible to a reader
+func (s textState) hidden(tm matrix, background color, backgroundKnown bool) bool {
+	size := s.fontSize * math.Hypot(tm[2], tm[3])
+	if s.renderMode == invisibleRenderMode || (size > 0 && size < MinVisibleFontSize) {
+		return true
+	}
+	return s.fillKnown && backgroundKnown && s.fill.matches(background)
+}
+
+// backgroundAt returns the color painted last under a point, or the white page
+func backgroundAt(painted []paintedRect, x, y float64) (color, bool) {
+	for i := len(painted) - 1; i >= 0; i-- {
+		r := painted[i]
+		if x >= r.minX && x <= r.maxX && y >= r.minY && y <= r.maxY {
+			return r.fill, r.known
+		}
+	}
+	return white, true
+}
+
+// DetectHiddenTextPDF finds text drawn in the color of the background behind it, with invisible
+// render mode or in tiny fonts. Backgrounds are the filled rectangles painted before the text,
+// such as a colored header or sidebar, or else the white page.
+func DetectHiddenTextPDF(filePath string) ([]string, error) {
+	f, r, err := pdf.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hidden := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for pageNum := 1; pageNum <= r.NumPage(); pageNum++ {
+		page := r.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+
+		for _, text := range hiddenTextOnPage(page) {
+			if !seen[text] {
+				seen[text] = true
+				hidden = append(hidden, text)
+			}
+		}
+	}
+
+	return hidden, nil
+}
+
+// hiddenTextOnPage interprets a page's content stream and collects invisible text runs
+func hiddenTextOnPage(page pdf.Page) (runs []string) {
+	defer func() {
+		// Malformed content streams panic inside the pdf library; keep the runs found so far
+		recover()
+	}()
+
+	fonts := make(map[string]pdf.TextEncoding)
+	for _, name := range page.Fonts() {
+		fonts[name] = page.Font(name).Encoder()
+	}
+
+	var enc pdf.TextEncoding
+	state := textState{fillKnown: true, ctm: identity} // The initial fill is black
+	var stack []textState
+	var current strings.Builder
+
+	// Text position; the text matrices are not part of the saved graphics state
+	tm, tlm := identity, identity
+	leading := 0.0
+
+	// Rectangles in the current path and those already filled
+	var path, painted []paintedRect
+
+	flush := func() {
+		if text := strings.Join(strings.Fields(current.String()), " "); text != "" {
+			runs = append(runs, text)
+		}
+		current.Reset()
+	}
+
+	show := func(raw string) {
+		x, y := tm.multiply(state.ctm).apply(0, 0)
+		background, known := backgroundAt(painted, x, y)
+		if !state.hidden(tm, background, known) {
+			flush()
+			return
+		}
+		if enc != nil {
+			raw = enc.Decode(raw)
+		}
+		current.WriteString(raw)
+		current.WriteString(" ")
+	}
+
+	pdf.Interpret(page.V.Key("Contents"), func(stk *pdf.Stack, op string) {
+		n := stk.Len()
+		args := make([]pdf.Value, n)
+		for i := n - 1; i >= 0; i-- {
+			args[i] = stk.Pop()
+		}
+
+		switch op {
+		case "q": // save graphics state
+			stack = append(stack, state)
+		case "Q": // restore graphics state
+			if len(stack) > 0 {
+				state = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm": // concatenate to the current transformation matrix
+			if len(args) == 6 {
+				var m matrix
+				for i := range m {
+					m[i] = args[i].Float64()
+				}
+				state.ctm = m.multiply(state.ctm)
+			}
+		case "re": // append a rectangle to the path
+			if len(args) == 4 {
+				x, y, w, h := args[0].Float64(), args[1].Float64(), args[2].Float64(), args[3].Float64()
+				r := paintedRect{minX: math.Inf(1), minY: math.Inf(1), maxX: math.Inf(-1), maxY: math.Inf(-1)}
+				for _, corner := range [][2]float64{{x, y}, {x + w, y}, {x, y + h}, {x + w, y + h}} {
+					px, py := state.ctm.apply(corner[0], corner[1])
+					r.minX, r.maxX = math.Min(r.minX, px), math.Max(r.maxX, px)
+					r.minY, r.maxY = math.Min(r.minY, py), math.Max(r.maxY, py)
+				}
+				path = append(path, r)
+			}
+		case "f", "F", "f*", "B", "B*", "b", "b*": // fill the path
+			for _, r := range path {
+				r.fill, r.known = state.fill, state.fillKnown
+				painted = append(painted, r)
+			}
+			path = nil
+		case "n", "S", "s": // end the path without filling it
+			path = nil
+		case "BT": // begin text object resets the text matrices
+			tm, tlm = identity, identity
+		case "g", "rg", "k", "sc", "scn": // set fill color
+			state.fill, state.fillKnown = fillColor(op, args)
+		case "Tr": // set text render mode
+			if len(args) == 1 {
+				state.renderMode = int(args[0].Float64())
+			}
+		case "Tf": // set text font and size
+			if len(args) == 2 {
+				enc = fonts[args[0].Name()]
+				state.fontSize = args[1].Float64()
+			}
+		case "Tm": // set text matrix
+			if len(args) == 6 {
+				for i := range tm {
+					tm[i] = args[i].Float64()
+				}
+				tlm = tm
+			}
+		case "TL": // set text leading
+			if len(args) == 1 {
+				leading = args[0].Float64()
+			}
+		case "Td", "TD": // move to the next line by an offset
+			if len(args) == 2 {
+				if op == "TD" {
+					leading = -args[1].Float64()
+				}
+				tlm = matrix{1, 0, 0, 1, args[0].Float64(), args[1].Float64()}.multiply(tlm)
+				tm = tlm
+			}
+		case "T*": // move to the next line
+			tlm = matrix{1, 0, 0, 1, 0, -leading}.multiply(tlm)
+			tm = tlm
+		case "Tj", "'", "\"": // show text
+			if op != "Tj" {
+				tlm = matrix{1, 0, 0, 1, 0, -leading}.multiply(tlm)
+				tm = tlm
+			}
+			if len(args) > 0 {
+				show(args[len(args)-1].RawString())
+			}
+		case "TJ": // show text with glyph positioning
+			if len(args) == 1 {
+				v := args[0]
+				for i := 0; i < v.Len(); i++ {
+					if x := v.Index(i); x.Kind() == pdf.String {
+						show(x.RawString())
+					}
+				}
+			}
+		}
+	})
+	flush()
+
+	return runs
+}
+
+// fillColor converts a fill color operator's operands to RGB. It reports false for colors
+// it can't read, such as patterns.
+func fillColor(op string, args []pdf.Value) (color, bool) {
+	components := make([]float64, 0, len(args))
+	for _, arg := range args {
+		// Pattern color spaces pass a name operand
+		if arg.Kind() != pdf.Real && arg.Kind() != pdf.Integer {
+			return color{}, false
+		}
+		components = append(components, arg.Float64())
+	}
+
+	switch {
+	case len(components) == 4 && (op == "k" || op == "sc" || op == "scn"):
+		c, m, y, k := components[0], components[1], components[2], components[3]
+		return color{(1 - c) * (1 - k), (1 - m) * (1 - k), (1 - y) * (1 - k)}, true
+	case len(components) == 3 && op != "k":
+		return color{components[0], components[1], components[2]}, true
+	case len(components) == 1 && op != "k":
+		return color{components[0], components[0], components[0]}, true
+	default:
+		return color{}, false
+	}
+}
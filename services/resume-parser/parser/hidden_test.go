@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeTestPDF writes a one-page PDF with the given content stream and returns its path
+func writeTestPDF(t *testing.T, content string) string {
+	t.Helper()
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content)+1, content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+
+	var b strings.Builder
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	path := filepath.Join(t.TempDir(), "resume.pdf")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDetectHiddenTextPDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "black text on the page",
+			content: "BT /F1 11 Tf 72 700 Td (Visible text) Tj ET",
+			want:    []string{},
+		},
+		{
+			name:    "white text on the page",
+			content: "BT /F1 11 Tf 1 g 72 700 Td (Kubernetes Docker) Tj ET",
+			want:    []string{"Kubernetes Docker"},
+		},
+		{
+			name:    "white text on a colored header",
+			content: "0.1 0.2 0.5 rg 0 740 612 52 re f BT /F1 18 Tf 1 1 1 rg 72 760 Td (Jane Doe) Tj ET",
+			want:    []string{},
+		},
+		{
+			name:    "white text on a header drawn in a scaled coordinate system",
+			content: "q 2 0 0 2 0 0 cm 0 0 0 0.8 k 0 370 306 26 re f Q BT /F1 18 Tf 1 g 1 0 0 1 72 760 Tm (Jane Doe) Tj ET",
+			want:    []string{},
+		},
+		{
+			name:    "white text below a colored header",
+			content: "0.1 0.2 0.5 rg 0 740 612 52 re f BT /F1 11 Tf 1 g 72 400 Td (Python Python) Tj ET",
+			want:    []string{"Python Python"},
+		},
+		{
+			name:    "dark text on a matching dark sidebar",
+			content: "0.2 g 0 0 180 792 re f BT /F1 11 Tf 0.2 g 20 500 Td (Terraform) Tj ET",
+			want:    []string{"Terraform"},
+		},
+		{
+			name:    "tiny text",
+			content: "BT /F1 1 Tf 72 100 Td (AWS GCP Azure) Tj ET",
+			want:    []string{"AWS GCP Azure"},
+		},
+		{
+			name:    "invisible render mode",
+			content: "BT /F1 11 Tf 3 Tr 72 100 Td (Leadership) Tj ET",
+			want:    []string{"Leadership"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectHiddenTextPDF(writeTestPDF(t, tt.content))
+			if err != nil {
+				t.Fatalf("DetectHiddenTextPDF: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hidden text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/nguyenthenguyen/docx"
+)
+
+// Legacy parsing limits
+const (
+	// tableGapRatio is how many font sizes wide a gap must be to separate table columns
+	tableGapRatio = 2.5
+	// minTableCells is the fewest columns a line needs to read as a table row; two columns
+	// are usually a title with right-aligned dates
+	minTableCells = 3
+)
+
+var (
+	// docxUnreadPattern matches the DOCX tables and text boxes legacy parsers skip
+	docxUnreadPattern = regexp.MustCompile(`(?s)<w:tbl>.*?</w:tbl>|<w:txbxContent>.*?</w:txbxContent>`)
+	// docxParagraphPattern matches one DOCX paragraph
+	docxParagraphPattern = regexp.MustCompile(`(?s)<w:p[ >].*?</w:p>`)
+)
+
+// LegacyUnreadPDF finds the lines an older ATS parser would not read: table rows, which
+// it cannot place in reading order
+func LegacyUnreadPDF(filePath string) ([]string, error) {
+	f, r, err := pdf.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	unread := make([]string, 0)
+	for pageNum := 1; pageNum <= r.NumPage(); pageNum++ {
+		page := r.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+		for _, line := range linesOnPage(page) {
+			if line.cells >= minTableCells {
+				unread = append(unread, line.text)
+			}
+		}
+	}
+	return unread, nil
+}
+
+// LegacyUnreadDOCX finds the paragraphs an older ATS parser would not read: those inside
+// tables and text boxes
+func LegacyUnreadDOCX(filePath string) ([]string, error) {
+	r, err := docx.ReadDocxFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	unread := make([]string, 0)
+	for _, block := range docxUnreadPattern.FindAllString(r.Editable().GetContent(), -1) {
+		for _, paragraph := range docxParagraphPattern.FindAllString(block, -1) {
+			if text := strings.Join(strings.Fields(cleanDocxContent(paragraph)), " "); text != "" {
+				unread = append(unread, text)
+			}
+		}
+	}
+	return unread, nil
+}
@@ -67,41 +67,19 @@ func NormalizeText(text string) string {
 		line = strings.Join(strings.Fields(line), " ")
 		line = strings.TrimSpace(line)
 
-		// Keep non-empty lines
+		// Keep non-empty lines, and one blank line between blocks since it helps spot headings
 		if line != "" {
 			result = append(result, line)
+		} else if len(result) > 0 && result[len(result)-1] != "" {
+			result = append(result, "")
 		}
 	}
 
-	// Join with newlines to preserve line structure for section detection
-	return strings.Join(result, "\n")
-}
-
-// DetectSections identifies resume sections from text
-func DetectSections(text string) []string {
-	sectionPatterns := []string{
-		"experience",
-		"education",
-		"skills",
-		"projects",
-		"work history",
-		"professional experience",
-		"technical skills",
-		"certifications",
-		"achievements",
-		"summary",
-		"objective",
-		"contact",
-	}
-
-	textLower := strings.ToLower(text)
-	var foundSections []string
-
-	for _, pattern := range sectionPatterns {
-		if strings.Contains(textLower, pattern) {
-			foundSections = append(foundSections, pattern)
-		}
+	// Drop a trailing blank line
+	if len(result) > 0 && result[len(result)-1] == "" {
+		result = result[:len(result)-1]
 	}
 
-	return foundSections
+	// Join with newlines to preserve line structure for section detection
+	return strings.Join(result, "\n")
 }
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	sharedlang "github.com/kedar/ats-checker/shared/lang"
+)
+
+// parserLanguage is what the parser needs to find the headings of one language
+type parserLanguage struct {
+	profile  sharedlang.Profile
+	patterns []sharedlang.SectionPattern // The language's headings plus English ones
+}
+
+var (
+	// languages are the built-in languages shared with nlp-service, plus any in LANGUAGE_DIR
+	languages = loadLanguages()
+	// languageProfiles are the detection profiles of every language, in code order
+	languageProfiles = detectionProfiles(languages)
+)
+
+// loadLanguages reads the language files and resolves each language's heading patterns the
+// way nlp-service does: its own headings plus English ones
+func loadLanguages() map[string]parserLanguage {
+	files := sharedlang.LoadFiles(os.Getenv("LANGUAGE_DIR"))
+	english := files[sharedlang.English].SectionHeadings
+
+	loaded := make(map[string]parserLanguage, len(files))
+	for code, file := range files {
+		extra := english
+		if code == sharedlang.English {
+			extra = nil
+		}
+		loaded[code] = parserLanguage{
+			profile:  sharedlang.NewProfile(file),
+			patterns: sharedlang.SectionPatterns(file.SectionHeadings, extra),
+		}
+	}
+	return loaded
+}
+
+// detectionProfiles lists the languages' detection profiles in code order, as nlp-service does
+func detectionProfiles(loaded map[string]parserLanguage) []sharedlang.Profile {
+	codes := make([]string, 0, len(loaded))
+	for code := range loaded {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	profiles := make([]sharedlang.Profile, 0, len(codes))
+	for _, code := range codes {
+		profiles = append(profiles, loaded[code].profile)
+	}
+	return profiles
+}
+
+// headingPatterns returns the heading patterns for the language text is written in, detected
+// as nlp-service detects it
+func headingPatterns(text string) []sharedlang.SectionPattern {
+	code, _ := sharedlang.Detect(text, languageProfiles, sharedlang.English)
+	return languages[code].patterns
+}
+
+// DetectSections finds the section headings in a resume and returns the heading text matched,
+// lower case, in order of appearance. Like nlp-service, it detects the resume's language and
+// recognizes that language's headings and English ones. headingHints are lines found in bold
+// or larger fonts.
+func DetectSections(text string, headingHints []string) []string {
+	foundSections := make([]string, 0)
+	seen := make(map[string]bool)
+
+	add := func(heading string) {
+		heading = strings.ToLower(strings.Join(strings.Fields(heading), " "))
+		if !seen[heading] {
+			seen[heading] = true
+			foundSections = append(foundSections, heading)
+		}
+	}
+
+	patterns := headingPatterns(text)
+	lines := strings.Split(text, "\n")
+
+	// Text without line breaks has no heading lines to inspect
+	if len(lines) <= 1 {
+		matches := make([][]int, 0)
+		for _, section := range patterns {
+			matches = append(matches, sharedlang.FindHeadings(section.Pattern, text)...)
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i][0] < matches[j][0]
+		})
+		for _, loc := range matches {
+			add(text[loc[0]:loc[1]])
+		}
+		return foundSections
+	}
+
+	hints := sharedlang.HeadingHints(headingHints)
+
+	blankBefore := true
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			blankBefore = true
+			continue
+		}
+		emphasized := hints[sharedlang.NormalizeLine(line)]
+		previousBlank := blankBefore
+		blankBefore = false
+
+		if len(line) >= sharedlang.MaxHeadingLength {
+			continue
+		}
+
+		ctx := sharedlang.HeadingContext{BlankBefore: previousBlank, Emphasized: emphasized}
+		if match, ok := sharedlang.ClassifyHeading(line, patterns); ok && sharedlang.ScoreHeading(line, match.Confidence, ctx) >= sharedlang.HeadingThreshold {
+			add(match.Matched)
+		}
+	}
+
+	return foundSections
+}
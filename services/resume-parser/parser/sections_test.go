@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectSections(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		hints []string
+		want  []string
+	}{
+		{
+			name: "english resume",
+			text: "Jane Doe\n\nEXPERIENCE\nBuilt the payment services for our growing team of engineers\n\n" +
+				"Formation\nLed the formation of a new platform team\n\nEDUCATION\nBSc Computer Science",
+			want: []string{"experience", "education"},
+		},
+		{
+			name: "french resume",
+			text: "Marie Dupont\n\nProfil\nIngénieure logicielle avec une passion pour la qualité et les services évolutifs\n\n" +
+				"Expérience professionnelle\nJ'ai dirigé une équipe de cinq développeurs et livré le projet dans les délais\n\n" +
+				"Formation\nLicence en informatique à l'université\n\nSkills\nGo, Python",
+			want: []string{"profil", "expérience professionnelle", "formation", "skills"},
+		},
+		{
+			name:  "heading hint with different spacing",
+			text:  "Jane Doe\nBuilt the payment services for our growing team of engineers\nmy   projects\nRate limiter in Go",
+			hints: []string{"my projects"},
+			want:  []string{"projects"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectSections(tt.text, tt.hints); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectSections = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/kedar/ats-checker/shared
+
+go 1.21
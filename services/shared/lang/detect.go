@@ -0,0 +1,142 @@
+package lang
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// minDetectionLetters is the least amount of text worth running language detection on
+	minDetectionLetters = 20
+	// stopWordDetectionWeight balances stop word hits against n-gram similarity
+	stopWordDetectionWeight = 1.0
+	// minDetectionConfidence is the margin below which the fallback language is assumed
+	minDetectionConfidence = 0.05
+)
+
+// Profile is what language detection knows about one language
+type Profile struct {
+	Code      string
+	StopWords map[string]bool // Lower-case stop words
+	ngrams    map[string]float64
+}
+
+// NewProfile builds a language's detection profile from its sample text and stop words
+func NewProfile(lang File) Profile {
+	stopWords := make(map[string]bool, len(lang.StopWords))
+	for _, word := range lang.StopWords {
+		stopWords[strings.ToLower(word)] = true
+	}
+	return Profile{
+		Code:      strings.ToLower(lang.Code),
+		StopWords: stopWords,
+		// Stop words are the most distinctive n-grams of a language, so they join the sample
+		ngrams: ngramProfile(lang.Sample + " " + strings.Join(lang.StopWords, " ")),
+	}
+}
+
+// Detect identifies the language of text offline using character n-gram profiles and stop
+// words. It returns the code of the best profile and its margin over the runner-up, 0-1; text
+// too short or too ambiguous to tell reads as fallback. The same profiles, in the same order,
+// always give the same answer.
+func Detect(text string, profiles []Profile, fallback string) (string, float64) {
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	if letters < minDetectionLetters {
+		return fallback, 0
+	}
+
+	ngrams := ngramProfile(text)
+	words := strings.Fields(strings.ToLower(text))
+
+	type candidate struct {
+		code  string
+		score float64
+	}
+	var candidates []candidate
+
+	for _, profile := range profiles {
+		if len(profile.ngrams) == 0 {
+			continue
+		}
+
+		stopHits := 0
+		for _, word := range words {
+			if profile.StopWords[strings.Trim(word, ".,;:!?()\"'")] {
+				stopHits++
+			}
+		}
+		stopRatio := float64(stopHits) / float64(len(words))
+
+		candidates = append(candidates, candidate{
+			code:  profile.Code,
+			score: profileSimilarity(ngrams, profile.ngrams) + stopWordDetectionWeight*stopRatio,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return fallback, 0
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	best := candidates[0]
+	confidence := 1.0
+	if len(candidates) > 1 && best.score > 0 {
+		confidence = math.Round((best.score-candidates[1].score)/best.score*100) / 100
+	}
+
+	// Keyword lists and other ambiguous text read as the fallback language
+	if confidence < minDetectionConfidence {
+		return fallback, confidence
+	}
+	return best.code, confidence
+}
+
+// ngramProfile builds a normalized frequency vector of character 1- to 3-grams over the words of text
+func ngramProfile(text string) map[string]float64 {
+	profile := make(map[string]float64)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		// Pad so n-grams capture word starts and endings
+		runes := []rune(" " + word + " ")
+		for n := 1; n <= 3; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				profile[string(runes[i:i+n])]++
+			}
+		}
+	}
+
+	var norm float64
+	for _, count := range profile {
+		norm += count * count
+	}
+	norm = math.Sqrt(norm)
+	for gram := range profile {
+		profile[gram] /= norm
+	}
+
+	return profile
+}
+
+// profileSimilarity is the cosine similarity of two normalized n-gram profiles
+func profileSimilarity(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var dot float64
+	for gram, weight := range a {
+		dot += weight * b[gram]
+	}
+	return dot
+}
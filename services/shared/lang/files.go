@@ -0,0 +1,69 @@
+package lang
+
+import (
+	"encoding/json"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// English is the language whose headings are recognized in resumes of every language
+const English = "en"
+
+// File is one language file: the words and headings a language uses
+type File struct {
+	Code         string   `json:"code"`
+	Name         string   `json:"name"`
+	StopWords    []string `json:"stopWords"`
+	StemSuffixes []string `json:"stemSuffixes"` // Suffixes stripped by the light stemmer
+	Sample       string   `json:"sample"`       // Representative text used to build the n-gram profile
+	// SectionHeadings maps a section name to the headings that introduce it
+	SectionHeadings map[string][]string `json:"sectionHeadings"`
+}
+
+// LoadFiles reads the built-in language files, then the files in dir, which add languages or
+// replace built-in ones. Languages are keyed by lower-case code; a file without a code is named
+// after the file. Files that can't be read or decoded are logged and skipped.
+func LoadFiles(dir string) map[string]File {
+	loaded := make(map[string]File)
+
+	files, _ := fs.Glob(Files, "*.json")
+	for _, file := range files {
+		data, err := Files.ReadFile(file)
+		if err != nil {
+			log.Printf("Failed to read embedded language file %s: %v", file, err)
+			continue
+		}
+		addFile(loaded, file, data)
+	}
+
+	if dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				log.Printf("Failed to read language file %s: %v", file, err)
+				continue
+			}
+			addFile(loaded, file, data)
+		}
+	}
+
+	return loaded
+}
+
+// addFile decodes a language file and stores it by its lower-case code
+func addFile(loaded map[string]File, file string, data []byte) {
+	var lang File
+	if err := json.Unmarshal(data, &lang); err != nil {
+		log.Printf("Invalid language file %s: %v", file, err)
+		return
+	}
+	if lang.Code == "" {
+		lang.Code = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	lang.Code = strings.ToLower(lang.Code)
+	loaded[lang.Code] = lang
+}
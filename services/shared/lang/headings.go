@@ -0,0 +1,121 @@
+package lang
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Heading feature weights; a line needs HeadingThreshold to count as a section heading
+const (
+	HeadingThreshold = 0.65
+
+	coverageWeight      = 0.5  // Share of the line covered by the heading match
+	shortLineWeight     = 0.15 // A few words at most
+	capitalizedWeight   = 0.15 // ALL CAPS or Title Case
+	trailingColonWeight = 0.1  // Ends with a colon
+	blankBeforeWeight   = 0.05 // Follows a blank line or starts the document
+	emphasizedWeight    = 0.15 // Set in bold or a larger font, according to the parser
+	noSentenceWeight    = 0.1  // No sentence punctuation, percentages or figures
+
+	// MaxHeadingLength is the longest line, in bytes, still considered a section heading
+	MaxHeadingLength = 60
+	// MaxHeadingWords is the longest a heading can be and still count as short
+	MaxHeadingWords = 4
+	// minHeadingCoverage is the least of the line a heading match must cover
+	minHeadingCoverage = 0.4
+)
+
+// BulletPrefixes mark a line as a bullet rather than a heading or title
+var BulletPrefixes = []string{"-", "*", "•", "▪", "◦", "●", "–"}
+
+// HeadingContext describes where a candidate heading line sits in the document
+type HeadingContext struct {
+	BlankBefore bool // The previous line is blank or this is the first line
+	Emphasized  bool // The parser found the line in bold or a larger font
+}
+
+// HeadingCoverage returns the share of line, 0-1 to two decimals, covered by a heading match
+// of matchLength runes. Punctuation and numbering around the heading don't count.
+func HeadingCoverage(line string, matchLength int) float64 {
+	core := strings.TrimFunc(line, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+	length := utf8.RuneCountInString(core)
+	if length == 0 {
+		return 0
+	}
+	coverage := float64(matchLength) / float64(length)
+	if coverage > 1 {
+		coverage = 1
+	}
+	return roundHundredth(coverage)
+}
+
+// ScoreHeading rates, 0-1, how likely a line matching a section heading is to really be a heading,
+// so body lines like "Improved user experience by 30%" do not start a new section.
+// It returns 0 for bullets and lines where the heading covers only a small part of the text.
+func ScoreHeading(line string, coverage float64, ctx HeadingContext) float64 {
+	line = strings.TrimSpace(line)
+	if line == "" || IsBullet(line) || coverage < minHeadingCoverage {
+		return 0
+	}
+
+	score := coverageWeight * coverage
+	words := strings.Fields(strings.TrimSuffix(line, ":"))
+
+	if len(words) <= MaxHeadingWords {
+		score += shortLineWeight
+	}
+	if isCapitalized(words) {
+		score += capitalizedWeight
+	}
+	if strings.HasSuffix(line, ":") {
+		score += trailingColonWeight
+	}
+	if ctx.BlankBefore {
+		score += blankBeforeWeight
+	}
+	if ctx.Emphasized {
+		score += emphasizedWeight
+	}
+	if !strings.ContainsAny(strings.TrimSuffix(line, ":"), ".,;!?%0123456789") {
+		score += noSentenceWeight
+	}
+
+	if score > 1 {
+		score = 1
+	}
+	return roundHundredth(score)
+}
+
+// IsBullet reports whether a line starts with a bullet
+func IsBullet(line string) bool {
+	for _, prefix := range BulletPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCapitalized reports whether every word is upper case or starts with a capital letter.
+// Short connecting words ("and", "of", "&") may stay lower case.
+func isCapitalized(words []string) bool {
+	capitalized := 0
+	for _, word := range words {
+		first := []rune(word)[0]
+		switch {
+		case unicode.IsUpper(first):
+			capitalized++
+		case unicode.IsLetter(first) && len(word) > 3:
+			return false
+		}
+	}
+	return capitalized > 0
+}
+
+// roundHundredth rounds a non-negative score to two decimals
+func roundHundredth(value float64) float64 {
+	return float64(int(value*100+0.5)) / 100
+}
@@ -0,0 +1,45 @@
+package lang
+
+import "testing"
+
+func TestScoreHeading(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		coverage float64
+		ctx      HeadingContext
+		want     float64
+	}{
+		{"every signal is clamped to 1", "SKILLS:", 1, HeadingContext{BlankBefore: true, Emphasized: true}, 1},
+		{"plain heading", "Experience", 1, HeadingContext{BlankBefore: true}, 0.95},
+		{"body line", "Improved user experience by 30%", 0.31, HeadingContext{}, 0},
+		{"bullet", "- Experience", 1, HeadingContext{BlankBefore: true}, 0},
+		{"lower case heading in a sentence", "work experience in retail, 2019", 0.5, HeadingContext{}, 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScoreHeading(tt.line, tt.coverage, tt.ctx); got != tt.want {
+				t.Errorf("ScoreHeading(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingCoverage(t *testing.T) {
+	tests := []struct {
+		line        string
+		matchLength int
+		want        float64
+	}{
+		{"EXPERIENCE:", 10, 1},
+		{"Work Experience", 10, 0.67},
+		{"", 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := HeadingCoverage(tt.line, tt.matchLength); got != tt.want {
+			t.Errorf("HeadingCoverage(%q, %d) = %v, want %v", tt.line, tt.matchLength, got, tt.want)
+		}
+	}
+}
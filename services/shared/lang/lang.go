@@ -0,0 +1,58 @@
+// Package lang holds the language files shared by resume-parser and nlp-service, and the
+// language detection and heading matching and scoring built on them, so both services pick
+// the same language and read section headings the same way
+package lang
+
+import (
+	"embed"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Files are the built-in <code>.json language files; LANGUAGE_DIR can add to or override them
+//
+//go:embed *.json
+var Files embed.FS
+
+// HeadingPattern builds a case-insensitive pattern matching any of the headings, longest first.
+// Use FindHeadings to match it on whole words.
+func HeadingPattern(headings []string) *regexp.Regexp {
+	alternatives := make([]string, 0, len(headings))
+	for _, heading := range headings {
+		words := strings.Fields(strings.ToLower(heading))
+		for i, word := range words {
+			words[i] = regexp.QuoteMeta(word)
+		}
+		if len(words) > 0 {
+			alternatives = append(alternatives, strings.Join(words, `\s+`))
+		}
+	}
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		return len(alternatives[i]) > len(alternatives[j])
+	})
+	return regexp.MustCompile(`(?i)(` + strings.Join(alternatives, "|") + `)`)
+}
+
+// FindHeadings returns the locations of heading matches that start and end on word boundaries,
+// so "skills" doesn't match inside "skillset". RE2's \b only knows ASCII letters, which would
+// break headings such as "Educación", so the boundaries are checked here.
+func FindHeadings(pattern *regexp.Regexp, text string) [][]int {
+	matches := make([][]int, 0)
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		before, _ := utf8.DecodeLastRuneInString(text[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(text[loc[1]:])
+		if isWordRune(before) || isWordRune(after) {
+			continue
+		}
+		matches = append(matches, loc)
+	}
+	return matches
+}
+
+// isWordRune reports whether r continues a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
@@ -0,0 +1,35 @@
+package lang
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindHeadings(t *testing.T) {
+	pattern := HeadingPattern([]string{"skills", "technical skills", "educación", "experience"})
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Technical Skills", []string{"Technical Skills"}},
+		{"SKILLS:", []string{"SKILLS"}},
+		{"Skillset", []string{}},
+		{"Educación", []string{"Educación"}},
+		{"Educaciónal", []string{}},
+		{"Inexperienced", []string{}},
+		{"Work Experience", []string{"Experience"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, loc := range FindHeadings(pattern, tt.text) {
+				got = append(got, tt.text[loc[0]:loc[1]])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindHeadings(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
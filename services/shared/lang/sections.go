@@ -0,0 +1,113 @@
+package lang
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SectionPriority orders sections for breaking ties between equally long heading matches
+var SectionPriority = []string{
+	"experience", "education", "skills", "projects", "certifications", "achievements", "summary",
+}
+
+// SectionPattern matches the headings that introduce one section
+type SectionPattern struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// HeadingMatch is the result of classifying a heading line
+type HeadingMatch struct {
+	Section    string  `json:"section"`
+	Matched    string  `json:"matched"`    // Text that matched the heading pattern
+	Confidence float64 `json:"confidence"` // Share of the line covered by the match, 0-1
+}
+
+// SectionPatterns compiles the heading patterns for a resume in one language: its own headings
+// plus English ones, which are common in non-English resumes. Pass nil english for English
+// itself. Sections come in SectionPriority order, then any others alphabetically.
+func SectionPatterns(headings, english map[string][]string) []SectionPattern {
+	merged := make(map[string][]string)
+	for section, names := range headings {
+		merged[section] = append(merged[section], names...)
+	}
+	for section, names := range english {
+		merged[section] = append(merged[section], names...)
+	}
+
+	names := make([]string, 0, len(merged))
+	for _, name := range SectionPriority {
+		if _, ok := merged[name]; ok {
+			names = append(names, name)
+		}
+	}
+	extra := make([]string, 0)
+	for name := range merged {
+		if sectionPriorityIndex(name) < 0 {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	names = append(names, extra...)
+
+	patterns := make([]SectionPattern, 0, len(names))
+	for _, name := range names {
+		patterns = append(patterns, SectionPattern{Name: name, Pattern: HeadingPattern(merged[name])})
+	}
+	return patterns
+}
+
+// sectionPriorityIndex returns the position of a section in SectionPriority, or -1
+func sectionPriorityIndex(name string) int {
+	for i, section := range SectionPriority {
+		if section == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ClassifyHeading finds the longest heading in line and the share of the line it covers.
+// Ties go to the section listed first in patterns, so the result never depends on map order.
+func ClassifyHeading(line string, patterns []SectionPattern) (HeadingMatch, bool) {
+	var best HeadingMatch
+	bestLength := 0
+
+	for _, section := range patterns {
+		for _, loc := range FindHeadings(section.Pattern, line) {
+			// Only a strictly longer match replaces the best
+			if length := utf8.RuneCountInString(line[loc[0]:loc[1]]); length > bestLength {
+				bestLength = length
+				best = HeadingMatch{Section: section.Name, Matched: line[loc[0]:loc[1]]}
+			}
+		}
+	}
+
+	if bestLength == 0 {
+		return HeadingMatch{}, false
+	}
+
+	// Punctuation and numbering around a heading do not lower confidence
+	best.Confidence = HeadingCoverage(line, bestLength)
+	return best, true
+}
+
+// NormalizeLine collapses the whitespace in a line, so lines compare equal however the
+// extractor spaced them
+func NormalizeLine(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// HeadingHints builds a lookup of lines the parser found in bold or larger fonts; look lines
+// up with NormalizeLine
+func HeadingHints(hints []string) map[string]bool {
+	set := make(map[string]bool, len(hints))
+	for _, hint := range hints {
+		if hint = NormalizeLine(hint); hint != "" {
+			set[hint] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,90 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyHeading(t *testing.T) {
+	files := LoadFiles("")
+	english := SectionPatterns(files[English].SectionHeadings, nil)
+	french := SectionPatterns(files["fr"].SectionHeadings, files[English].SectionHeadings)
+
+	tests := []struct {
+		name     string
+		line     string
+		patterns []SectionPattern
+		want     string
+	}{
+		{"english heading", "Work Experience", english, "experience"},
+		{"tie goes to the higher priority section", "Skills & Experience", english, "experience"},
+		{"french heading in an english resume", "Formation", english, ""},
+		{"french heading in a french resume", "Formation", french, "education"},
+		{"english heading in a french resume", "Skills", french, "skills"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, _ := ClassifyHeading(tt.line, tt.patterns)
+			if match.Section != tt.want {
+				t.Errorf("ClassifyHeading(%q) = %q, want %q", tt.line, match.Section, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingHints(t *testing.T) {
+	hints := HeadingHints([]string{"  WORK   EXPERIENCE ", "", "Skills"})
+	for _, line := range []string{"WORK EXPERIENCE", "WORK\tEXPERIENCE", " Skills "} {
+		if !hints[NormalizeLine(line)] {
+			t.Errorf("%q is not a hint", line)
+		}
+	}
+	if len(hints) != 2 {
+		t.Errorf("hints = %v, want 2 entries", hints)
+	}
+}
+
+func TestLoadFilesOverride(t *testing.T) {
+	dir := t.TempDir()
+	file := `{"code": "EN", "name": "English (custom)", "sectionHeadings": {"skills": ["toolbox"]}}`
+	if err := os.WriteFile(filepath.Join(dir, "custom.json"), []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := LoadFiles(dir)
+	if got := files[English].Name; got != "English (custom)" {
+		t.Errorf("en is %q, want the custom file to replace it", got)
+	}
+	if _, ok := files["EN"]; ok {
+		t.Errorf("custom file kept under its upper-case code")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	files := LoadFiles("")
+	profiles := make([]Profile, 0, len(files))
+	for _, code := range []string{"de", "en", "es", "fr", "pt"} {
+		profiles = append(profiles, NewProfile(files[code]))
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Built and maintained the payment services for our growing team of engineers", "en"},
+		{"french", "J'ai dirigé une équipe de cinq développeurs et livré le projet dans les délais", "fr"},
+		{"spanish", "Desarrollé servicios de pagos para el equipo y mejoré el rendimiento de las bases de datos", "es"},
+		{"too short", "Go, SQL", English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := Detect(tt.text, profiles, English); got != tt.want {
+				t.Errorf("Detect = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}